@@ -2,6 +2,7 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
@@ -10,17 +11,25 @@ import (
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/oklog/run"
+	"golang.org/x/sync/errgroup"
 
 	appgenerate "github.com/slok/stactus/internal/app/generate"
 	"github.com/slok/stactus/internal/conventions"
 	"github.com/slok/stactus/internal/dev"
+	"github.com/slok/stactus/internal/log"
+	"github.com/slok/stactus/internal/model"
 	"github.com/slok/stactus/internal/storage"
+	"github.com/slok/stactus/internal/storage/badge"
 	"github.com/slok/stactus/internal/storage/feed"
 	htmlcommon "github.com/slok/stactus/internal/storage/html/common"
 	htmlsimple "github.com/slok/stactus/internal/storage/html/themes/simple"
 	themesimple "github.com/slok/stactus/internal/storage/html/themes/simple"
 	"github.com/slok/stactus/internal/storage/iofs"
+	"github.com/slok/stactus/internal/storage/jsondata"
+	"github.com/slok/stactus/internal/storage/ogimage"
 	"github.com/slok/stactus/internal/storage/prometheus"
+	"github.com/slok/stactus/internal/storage/widget"
+	utilfs "github.com/slok/stactus/internal/util/fs"
 )
 
 const (
@@ -31,10 +40,12 @@ type GeneretaCommand struct {
 	cmd        *kingpin.CmdClause
 	rootConfig *RootCommand
 
-	stactusFilePath string
-	outPath         string
-	siteURL         string
-	devFixtures     bool
+	stactusFilePath   string
+	workspaceFilePath string
+	outPath           string
+	siteURL           string
+	devFixtures       bool
+	audience          string
 }
 
 const (
@@ -50,9 +61,11 @@ func NewGeneretaCommand(rootConfig *RootCommand, app *kingpin.Application) *Gene
 	}
 
 	cmd.Flag("stactus-file", "The path ot the stactus file.").Short('i').Default(defaultStactusFile).StringVar(&c.stactusFilePath)
+	cmd.Flag("workspace-file", "The path of the workspace file, if set, all the workspace status pages will be generated (each one on its own directory) instead of the stactus file.").Short('w').StringVar(&c.workspaceFilePath)
 	cmd.Flag("out", "The directory where all the generated files will be written.").Required().Short('o').StringVar(&c.outPath)
 	cmd.Flag("site-url", "The site base url, if set it will override the one on the stactus configuration.").StringVar(&c.siteURL)
 	cmd.Flag("dev-fixtures", "If enabled it will load development fixtures.").BoolVar(&c.devFixtures)
+	cmd.Flag("audience", "The audience of the status page, internal will have the internal incidents too. By default all the audiences of the stactus file will be generated (each one on its own directory) or public if none.").EnumVar(&c.audience, string(model.AudiencePublic), string(model.AudienceInternal))
 
 	return c
 }
@@ -64,133 +77,352 @@ func (c *GeneretaCommand) Run(ctx context.Context) (err error) {
 
 	logger := c.rootConfig.Logger
 
-	// Open stactus file.
-	stactusFileData, err := os.ReadFile(c.stactusFilePath)
+	// Load the status pages to generate.
+	statusPages, err := c.loadStatusPages(ctx)
 	if err != nil {
-		return fmt.Errorf("could not load stactus file: %w", err)
+		return err
 	}
 
-	// Setup repository.
-	var (
-		repoSystemGetter   storage.SystemGetter
-		repoIRGetter       storage.IncidentReportGetter
-		repoSettingsGetter storage.StatusPageSettingsGetter
-	)
+	// Prepare run entrypoints.
+	var g run.Group
 
-	if c.devFixtures {
-		devRepo := dev.NewAutogeneratedRepository()
-		repoSystemGetter = devRepo
-		repoIRGetter = devRepo
-		repoSettingsGetter = devRepo
-
-	} else {
-		d := path.Dir(c.stactusFilePath)
-		rootFS := os.DirFS(d)
-		incidentsFS, err := fs.Sub(rootFS, "incidents")
-		if err != nil {
-			return fmt.Errorf("incidents directory missing on at the same level of the stactus file: %w", err)
+	// Upper layer context handler.
+	{
+		g.Add(
+			func() error {
+				<-ctx.Done()
+				logger.Infof("Context cancelled...")
+				return nil
+			},
+			func(err error) {
+				cancel(err)
+			},
+		)
+	}
+
+	// Generate all the status pages.
+	{
+		g.Add(
+			func() error {
+				group, ctx := errgroup.WithContext(ctx)
+				for _, sp := range statusPages {
+					group.Go(func() error {
+						err := c.generateStatusPage(ctx, sp)
+						if err != nil && sp.id != "" {
+							return fmt.Errorf("%s status page: %w", sp.id, err)
+						}
+						return err
+					})
+				}
+
+				return group.Wait()
+			},
+			func(err error) {},
+		)
+	}
+
+	return g.Run()
+}
+
+// loadStatusPages loads the status page of the stactus file or all the status pages of the workspace.
+func (c *GeneretaCommand) loadStatusPages(ctx context.Context) ([]*statusPage, error) {
+	logger := c.rootConfig.Logger
+
+	if c.workspaceFilePath != "" {
+		if c.devFixtures {
+			return nil, fmt.Errorf("development fixtures can't be used with a workspace")
 		}
-		roRepo, err := iofs.NewReadRepository(ctx, iofs.ReadRepositoryConfig{
-			IncidentsFS:     incidentsFS,
-			StactusFileData: string(stactusFileData),
-			Logger:          logger,
-		})
+		if c.siteURL != "" {
+			return nil, fmt.Errorf("site URL can't be overridden when generating a workspace")
+		}
+
+		return loadWorkspaceStatusPages(ctx, c.workspaceFilePath, logger)
+	}
+
+	if c.devFixtures {
+		sp, err := newStatusPage(ctx, dev.NewAutogeneratedRepository())
 		if err != nil {
-			return fmt.Errorf("could not load data: %w", err)
+			return nil, err
 		}
+		return []*statusPage{sp}, nil
+	}
 
-		repoSystemGetter = roRepo
-		repoIRGetter = roRepo
-		repoSettingsGetter = roRepo
+	sp, err := loadFSStatusPage(ctx, fsStatusPageConfig{
+		stactusFilePath: c.stactusFilePath,
+		logger:          logger,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Override templates if required.
-	settings, err := repoSettingsGetter.GetStatusPageSettings(ctx)
+	return []*statusPage{sp}, nil
+}
+
+// generateStatusPage generates all the audiences of a status page.
+func (c *GeneretaCommand) generateStatusPage(ctx context.Context, sp *statusPage) error {
+	logger := c.rootConfig.Logger
+	if sp.id != "" {
+		logger = logger.WithValues(log.Kv{"page": sp.id})
+		logger.Infof("Generating status page")
+	}
+
+	// Select the audiences to generate.
+	builds, err := c.audienceBuilds(sp.settings, filepath.Join(c.outPath, sp.outPath))
 	if err != nil {
-		return fmt.Errorf("could not retrieve page status settings: %w", err)
+		return err
 	}
-	var themeRenderer *htmlcommon.ThemeRenderer
-	if settings.Theme.OverrideTPLPath != "" {
-		themeRenderer, err = htmlcommon.NewOSFSThemeRenderer(settings.Theme.OverrideTPLPath)
+
+	for _, b := range builds {
+		genService, err := newFSGenerateService(fsGenerateServiceConfig{
+			statusPage: sp,
+			outPath:    b.outPath,
+			logger:     logger,
+		})
 		if err != nil {
-			return fmt.Errorf("could not load custom templates theme: %w", err)
+			return fmt.Errorf("could not create generation service: %w", err)
 		}
+
+		_, err = genService.Generate(ctx, appgenerate.GenerateReq{
+			OverrideSiteURL: c.siteURL,
+			Audience:        b.audience,
+		})
+		if err != nil {
+			return fmt.Errorf("%s audience generation failed: %w", b.audience, err)
+		}
+	}
+
+	return nil
+}
+
+type audienceBuild struct {
+	audience model.Audience
+	outPath  string
+}
+
+// audienceBuilds returns the audiences to generate, if the audience is not selected, all the
+// configured audiences will be generated, each one on its own directory.
+func (c *GeneretaCommand) audienceBuilds(settings *model.StatusPageSettings, outPath string) ([]audienceBuild, error) {
+	if c.audience != "" {
+		return []audienceBuild{{audience: model.Audience(c.audience), outPath: outPath}}, nil
 	}
 
+	if len(settings.Audiences) == 0 {
+		return []audienceBuild{{audience: model.AudiencePublic, outPath: outPath}}, nil
+	}
+
+	if c.siteURL != "" {
+		return nil, fmt.Errorf("site URL can't be overridden when generating multiple audiences, select one with the audience flag")
+	}
+
+	builds := []audienceBuild{}
+	for _, a := range settings.Audiences {
+		builds = append(builds, audienceBuild{
+			audience: a.Audience,
+			outPath:  filepath.Join(outPath, string(a.Audience)),
+		})
+	}
+
+	return builds, nil
+}
+
+type fsGenerateServiceConfig struct {
+	statusPage  *statusPage
+	fileManager utilfs.FileManager // If not set, the files will be written on disk.
+	outPath     string
+	logger      log.Logger
+}
+
+// newFSGenerateService returns a generation service that writes all the resources on the output path.
+func newFSGenerateService(config fsGenerateServiceConfig) (*appgenerate.Service, error) {
+	sp := config.statusPage
+	settings := sp.settings
+
 	// Create the UI renderer.
 	var repoUICreator storage.UICreator
+	var err error
 	switch {
 	case settings.Theme.Simple != nil:
 		repoUICreator, err = themesimple.NewGenerator(htmlsimple.GeneratorConfig{
-			ThemeRenderer: themeRenderer,
-			OutPath:       c.outPath,
-			Logger:        logger,
+			ThemeRenderer: sp.themeRenderer,
+			Messages:      sp.themeMessages,
+			FileManager:   config.fileManager,
+			OutPath:       config.outPath,
+			Logger:        config.logger,
 		})
 		if err != nil {
-			return fmt.Errorf("could not create html generator: %w", err)
+			return nil, fmt.Errorf("could not create html generator: %w", err)
 		}
 	default:
-		return fmt.Errorf("unknown theme")
+		return nil, fmt.Errorf("unknown theme")
 	}
 
 	repoPromCreator, err := prometheus.NewFSRepository(prometheus.RepositoryConfig{
-		MetricsFilePath: filepath.Join(c.outPath, conventions.PrometheusMetricsPathName),
+		FileManager:     config.fileManager,
+		MetricsFilePath: filepath.Join(config.outPath, conventions.PrometheusMetricsPathName),
 	})
 	if err != nil {
-		return fmt.Errorf("could not create prometheus metrics creator: %w", err)
+		return nil, fmt.Errorf("could not create prometheus metrics creator: %w", err)
 	}
 
 	repoFeedCreator, err := feed.NewFSRepository(feed.RepositoryConfig{
-		AtomHistoryFilePath: filepath.Join(c.outPath, conventions.IRHistoryAtomFeedPathName),
+		FileManager:         config.fileManager,
+		OutPath:             config.outPath,
+		HistoryItemsPerFeed: settings.Feed.HistoryItems,
 	})
 	if err != nil {
-		return fmt.Errorf("could not create feed creator: %w", err)
+		return nil, fmt.Errorf("could not create feed creator: %w", err)
 	}
 
-	// Prepare run entrypoints.
-	var g run.Group
+	repoBadgeCreator, err := badge.NewFSRepository(badge.RepositoryConfig{
+		FileManager: config.fileManager,
+		OutPath:     config.outPath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not create badge creator: %w", err)
+	}
 
-	// Upper layer context handler.
-	{
-		g.Add(
-			func() error {
-				<-ctx.Done()
-				logger.Infof("Context cancelled...")
-				return nil
-			},
-			func(err error) {
-				cancel(err)
-			},
-		)
+	repoWidgetCreator, err := widget.NewFSRepository(widget.RepositoryConfig{
+		FileManager: config.fileManager,
+		OutPath:     config.outPath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not create widget creator: %w", err)
 	}
 
-	// Upper layer context handler.
-	{
-		genService, err := appgenerate.NewService(appgenerate.ServiceConfig{
-			SettingsGetter:     repoSettingsGetter,
-			SystemGetter:       repoSystemGetter,
-			IRGetter:           repoIRGetter,
-			UICreator:          repoUICreator,
-			PromMetricsCreator: repoPromCreator,
-			FeedCreator:        repoFeedCreator,
-			Logger:             logger,
-		})
+	repoDataCreator, err := jsondata.NewFSRepository(jsondata.RepositoryConfig{
+		FileManager: config.fileManager,
+		OutPath:     config.outPath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not create data creator: %w", err)
+	}
+
+	repoOGImageCreator, err := ogimage.NewFSRepository(ogimage.RepositoryConfig{
+		FileManager: config.fileManager,
+		OutPath:     config.outPath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not create OG image creator: %w", err)
+	}
+
+	return appgenerate.NewService(appgenerate.ServiceConfig{
+		SettingsGetter:     sp.repo,
+		SystemGetter:       sp.repo,
+		IRGetter:           sp.repo,
+		PageGetter:         sp.repo,
+		UICreator:          repoUICreator,
+		PromMetricsCreator: repoPromCreator,
+		FeedCreator:        repoFeedCreator,
+		BadgeCreator:       repoBadgeCreator,
+		WidgetCreator:      repoWidgetCreator,
+		DataCreator:        repoDataCreator,
+		OGImageCreator:     repoOGImageCreator,
+		Logger:             config.logger,
+	})
+}
+
+// statusPageRepository is the storage of the status page data.
+type statusPageRepository interface {
+	storage.StatusPageSettingsGetter
+	storage.SystemGetter
+	storage.IncidentReportGetter
+	storage.PageGetter
+}
+
+// statusPage is a loaded status page ready to be generated.
+type statusPage struct {
+	id            string // Only set on workspace status pages.
+	outPath       string // Relative to the generation out path.
+	repo          statusPageRepository
+	settings      *model.StatusPageSettings
+	themeRenderer *htmlcommon.ThemeRenderer
+	themeMessages htmlcommon.Messages
+}
+
+// newStatusPage returns a status page of the repository with its theme customizations loaded.
+func newStatusPage(ctx context.Context, repo statusPageRepository) (*statusPage, error) {
+	settings, err := repo.GetStatusPageSettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve page status settings: %w", err)
+	}
+
+	// Override templates if required.
+	var themeRenderer *htmlcommon.ThemeRenderer
+	if settings.Theme.OverrideTPLPath != "" {
+		themeRenderer, err = htmlcommon.NewOSFSThemeRenderer(settings.Theme.OverrideTPLPath)
 		if err != nil {
-			return fmt.Errorf("could not create generation service: %w", err)
+			return nil, fmt.Errorf("could not load custom templates theme: %w", err)
 		}
+	}
+	var themeMessages htmlcommon.Messages
+	if settings.Theme.TranslationsPath != "" {
+		themeMessages, err = htmlcommon.LoadMessages(os.DirFS(settings.Theme.TranslationsPath))
+		if err != nil {
+			return nil, fmt.Errorf("could not load custom translations: %w", err)
+		}
+	}
 
-		g.Add(
-			func() error {
-				_, err := genService.Generate(ctx, appgenerate.GenerateReq{OverrideSiteURL: c.siteURL})
-				if err != nil {
-					return fmt.Errorf("generation failed: %w", err)
-				}
+	return &statusPage{
+		repo:          repo,
+		settings:      settings,
+		themeRenderer: themeRenderer,
+		themeMessages: themeMessages,
+	}, nil
+}
 
-				return nil
-			},
-			func(err error) {},
-		)
+type fsStatusPageConfig struct {
+	stactusFilePath string
+	// incidentsFS is optional, if set the incidents of other systems will be ignored,
+	// if not set, the incidents directory at the same level of the stactus file will be used.
+	incidentsFS fs.FS
+	logger      log.Logger
+}
+
+// loadFSStatusPage loads a status page from the stactus file and the directories at the same level.
+func loadFSStatusPage(ctx context.Context, config fsStatusPageConfig) (*statusPage, error) {
+	// Open stactus file.
+	stactusFileData, err := os.ReadFile(config.stactusFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not load stactus file: %w", err)
 	}
 
-	return g.Run()
+	// Setup repository.
+	rootFS := os.DirFS(path.Dir(config.stactusFilePath))
+	incidentsFS := config.incidentsFS
+	if incidentsFS == nil {
+		incidentsFS, err = fs.Sub(rootFS, "incidents")
+		if err != nil {
+			return nil, fmt.Errorf("incidents directory missing on at the same level of the stactus file: %w", err)
+		}
+	}
+	pagesFS, err := pagesSubFS(rootFS)
+	if err != nil {
+		return nil, fmt.Errorf("could not load pages directory: %w", err)
+	}
+	roRepo, err := iofs.NewReadRepository(ctx, iofs.ReadRepositoryConfig{
+		IncidentsFS:             incidentsFS,
+		PagesFS:                 pagesFS,
+		StactusFileData:         string(stactusFileData),
+		FilterIncidentsBySystem: config.incidentsFS != nil,
+		Logger:                  config.logger,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not load data: %w", err)
+	}
+
+	return newStatusPage(ctx, roRepo)
+}
+
+// pagesSubFS returns the custom pages directory FS that is at the same level of the stactus file,
+// as pages are optional, if the directory doesn't exist it will return a nil FS.
+func pagesSubFS(rootFS fs.FS) (fs.FS, error) {
+	_, err := fs.Stat(rootFS, "pages")
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return fs.Sub(rootFS, "pages")
 }
@@ -91,6 +91,7 @@ func migrateStatusPageRepository(ctx context.Context, logger log.Logger, repo *m
 		if err != nil {
 			return fmt.Errorf("could not marshal to yaml stactus file: %w", err)
 		}
+		data = withYAMLSchemaHeader(data, apiv1.StactusV1SchemaURL)
 
 		// Write.
 		fpath := filepath.Join(outPath, "stactus.yaml")
@@ -146,6 +147,7 @@ func migrateStatusPageRepository(ctx context.Context, logger log.Logger, repo *m
 			if err != nil {
 				return fmt.Errorf("could not marshal to yaml %q incident: %w", ir.ID, err)
 			}
+			data = withYAMLSchemaHeader(data, apiv1.IncidentV1SchemaURL)
 
 			// Write.
 			fpath := filepath.Join(outPath, "incidents", ir.ID+".yaml")
@@ -160,3 +162,10 @@ func migrateStatusPageRepository(ctx context.Context, logger log.Logger, repo *m
 
 	return nil
 }
+
+// withYAMLSchemaHeader adds the schema header used by the editors (yaml-language-server) to
+// validate and autocomplete the YAML files.
+func withYAMLSchemaHeader(data []byte, schemaURL string) []byte {
+	header := fmt.Sprintf("# yaml-language-server: $schema=%s\n", schemaURL)
+	return append([]byte(header), data...)
+}
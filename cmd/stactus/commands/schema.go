@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alecthomas/kingpin/v2"
+
+	utilfs "github.com/slok/stactus/internal/util/fs"
+	apiv1 "github.com/slok/stactus/pkg/api/v1"
+)
+
+const (
+	schemaKindStactus   = "stactus"
+	schemaKindIncident  = "incident"
+	schemaKindWorkspace = "workspace"
+)
+
+type SchemaCommand struct {
+	cmd        *kingpin.CmdClause
+	rootConfig *RootCommand
+
+	kind    string
+	outPath string
+}
+
+// NewSchemaCommand returns the schema command.
+func NewSchemaCommand(rootConfig *RootCommand, app *kingpin.Application) *SchemaCommand {
+	cmd := app.Command("schema", "Shows the JSON schema of the YAML API files (can be used by editors to validate and autocomplete).")
+	c := &SchemaCommand{
+		cmd:        cmd,
+		rootConfig: rootConfig,
+	}
+
+	cmd.Arg("kind", "The kind of the YAML API file.").Required().EnumVar(&c.kind, schemaKindStactus, schemaKindIncident, schemaKindWorkspace)
+	cmd.Flag("out", "The file where the schema will be written, if not set it will be written to stdout.").Short('o').StringVar(&c.outPath)
+
+	return c
+}
+
+func (c SchemaCommand) Name() string { return c.cmd.FullCommand() }
+func (c SchemaCommand) Run(ctx context.Context) error {
+	var schema []byte
+	var err error
+	switch c.kind {
+	case schemaKindStactus:
+		schema, err = apiv1.StactusV1JSONSchema()
+	case schemaKindIncident:
+		schema, err = apiv1.IncidentV1JSONSchema()
+	case schemaKindWorkspace:
+		schema, err = apiv1.WorkspaceV1JSONSchema()
+	default:
+		err = fmt.Errorf("unknown schema kind %q", c.kind)
+	}
+	if err != nil {
+		return fmt.Errorf("could not generate schema: %w", err)
+	}
+
+	if c.outPath == "" {
+		_, err = c.rootConfig.Stdout.Write(schema)
+		return err
+	}
+
+	err = utilfs.StdFileManager.WriteFile(ctx, c.outPath, schema)
+	if err != nil {
+		return fmt.Errorf("could not write %q: %w", c.outPath, err)
+	}
+
+	return nil
+}
@@ -3,11 +3,10 @@ package commands
 import (
 	"context"
 	"fmt"
+	"html"
 	"io/fs"
 	"net/http"
-	"os"
 	"path"
-	"path/filepath"
 	"strconv"
 	"strings"
 	"testing/fstest"
@@ -16,23 +15,18 @@ import (
 	"github.com/oklog/run"
 
 	appgenerate "github.com/slok/stactus/internal/app/generate"
-	"github.com/slok/stactus/internal/conventions"
 	"github.com/slok/stactus/internal/log"
-	"github.com/slok/stactus/internal/storage"
-	"github.com/slok/stactus/internal/storage/feed"
-	htmlcommon "github.com/slok/stactus/internal/storage/html/common"
-	htmlsimple "github.com/slok/stactus/internal/storage/html/themes/simple"
-	themesimple "github.com/slok/stactus/internal/storage/html/themes/simple"
-	"github.com/slok/stactus/internal/storage/iofs"
-	"github.com/slok/stactus/internal/storage/prometheus"
+	"github.com/slok/stactus/internal/model"
 )
 
 type ServeCommand struct {
 	cmd        *kingpin.CmdClause
 	rootConfig *RootCommand
 
-	stactusFilePath string
-	listenAddress   string
+	stactusFilePath   string
+	workspaceFilePath string
+	listenAddress     string
+	audience          string
 }
 
 // NewServeCommand returns a generator with the github status page theme.
@@ -44,7 +38,9 @@ func NewServeCommand(rootConfig *RootCommand, app *kingpin.Application) *ServeCo
 	}
 
 	cmd.Flag("stactus-file", "The path ot the stactus file.").Short('i').Default(defaultStactusFile).StringVar(&c.stactusFilePath)
+	cmd.Flag("workspace-file", "The path of the workspace file, if set, all the workspace status pages will be served (each one under its ID path) instead of the stactus file.").Short('w').StringVar(&c.workspaceFilePath)
 	cmd.Flag("listen-address", "The address where the server will be listening.").Default(":8080").StringVar(&c.listenAddress)
+	cmd.Flag("audience", "The audience of the status page, internal will have the internal incidents too.").Default(string(model.AudiencePublic)).EnumVar(&c.audience, string(model.AudiencePublic), string(model.AudienceInternal))
 
 	return c
 }
@@ -75,88 +71,21 @@ func (c *ServeCommand) Run(ctx context.Context) (err error) {
 
 	// Development server.
 	{
-		// Open stactus file.
-		stactusFileData, err := os.ReadFile(c.stactusFilePath)
-		if err != nil {
-			return fmt.Errorf("could not load stactus file: %w", err)
-		}
-
-		// Setup repository.
-		d := path.Dir(c.stactusFilePath)
-		rootFS := os.DirFS(d)
-		incidentsFS, err := fs.Sub(rootFS, "incidents")
-		if err != nil {
-			return fmt.Errorf("incidents directory missing on at the same level of the stactus file: %w", err)
-		}
-		roRepo, err := iofs.NewReadRepository(ctx, iofs.ReadRepositoryConfig{
-			IncidentsFS:     incidentsFS,
-			StactusFileData: string(stactusFileData),
-			Logger:          logger,
-		})
-		if err != nil {
-			return fmt.Errorf("could not load data: %w", err)
-		}
-
-		// Override templates if required.
-		settings, err := roRepo.GetStatusPageSettings(ctx)
-		if err != nil {
-			return fmt.Errorf("could not retrieve page status settings: %w", err)
-		}
-		var themeRenderer *htmlcommon.ThemeRenderer
-		if settings.Theme.OverrideTPLPath != "" {
-			themeRenderer, err = htmlcommon.NewOSFSThemeRenderer(settings.Theme.OverrideTPLPath)
+		var statusPages []*statusPage
+		if c.workspaceFilePath != "" {
+			statusPages, err = loadWorkspaceStatusPages(ctx, c.workspaceFilePath, logger)
 			if err != nil {
-				return fmt.Errorf("could not load custom templates theme: %w", err)
+				return err
 			}
-		}
-
-		memFS := fstest.MapFS{}
-		memFileManager := &memFSFileManager{fs: memFS}
-
-		// Create the UI renderer.
-		var repoUICreator storage.UICreator
-		switch {
-		case settings.Theme.Simple != nil:
-			repoUICreator, err = themesimple.NewGenerator(htmlsimple.GeneratorConfig{
-				ThemeRenderer: themeRenderer,
-				FileManager:   memFileManager,
-				OutPath:       "./",
-				Logger:        logger,
+		} else {
+			sp, err := loadFSStatusPage(ctx, fsStatusPageConfig{
+				stactusFilePath: c.stactusFilePath,
+				logger:          logger,
 			})
 			if err != nil {
-				return fmt.Errorf("could not create html generator: %w", err)
+				return err
 			}
-		default:
-			return fmt.Errorf("unknown theme")
-		}
-
-		repoPromCreator, err := prometheus.NewFSRepository(prometheus.RepositoryConfig{
-			FileManager:     memFileManager,
-			MetricsFilePath: filepath.Join("./", conventions.PrometheusMetricsPathName),
-		})
-		if err != nil {
-			return fmt.Errorf("could not create prometheus metrics creator: %w", err)
-		}
-
-		repoFeedCreator, err := feed.NewFSRepository(feed.RepositoryConfig{
-			FileManager:         memFileManager,
-			AtomHistoryFilePath: filepath.Join("./", conventions.IRHistoryAtomFeedPathName),
-		})
-		if err != nil {
-			return fmt.Errorf("could not create feed creator: %w", err)
-		}
-
-		genService, err := appgenerate.NewService(appgenerate.ServiceConfig{
-			SettingsGetter:     roRepo,
-			SystemGetter:       roRepo,
-			IRGetter:           roRepo,
-			UICreator:          repoUICreator,
-			PromMetricsCreator: repoPromCreator,
-			FeedCreator:        repoFeedCreator,
-			Logger:             logger,
-		})
-		if err != nil {
-			return fmt.Errorf("could not create generation service: %w", err)
+			statusPages = []*statusPage{sp}
 		}
 
 		_, portS, _ := strings.Cut(c.listenAddress, ":")
@@ -165,24 +94,51 @@ func (c *ServeCommand) Run(ctx context.Context) (err error) {
 		}
 		address := "http://127.0.0.1:" + portS
 
-		_, err = genService.Generate(ctx, appgenerate.GenerateReq{OverrideSiteURL: address})
-		if err != nil {
-			return fmt.Errorf("generation failed: %w", err)
-		}
+		// Generate the status pages in memory, workspace status pages are served under their ID path.
+		mux := http.NewServeMux()
+		for _, sp := range statusPages {
+			siteURL := address
+			if sp.id != "" {
+				siteURL = address + "/" + sp.id
+			}
+
+			memFS := fstest.MapFS{}
+			genService, err := newFSGenerateService(fsGenerateServiceConfig{
+				statusPage:  sp,
+				fileManager: &memFSFileManager{fs: memFS},
+				outPath:     "./",
+				logger:      logger,
+			})
+			if err != nil {
+				return fmt.Errorf("could not create generation service: %w", err)
+			}
 
-		staticHandler := http.FileServerFS(memFS)
-		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// If index we need to change index.html because of this: https://github.com/golang/go/blob/3d33437c450aa74014ea1d41cd986b6ee6266984/src/net/http/fs.go#L680
-			if r.URL.Path == "" || r.URL.Path == "/" {
-				r.URL.Path = "index"
+			_, err = genService.Generate(ctx, appgenerate.GenerateReq{
+				OverrideSiteURL: siteURL,
+				Audience:        model.Audience(c.audience),
+				Preview:         true, // Drafts are shown while serving locally.
+			})
+			if err != nil {
+				return fmt.Errorf("generation failed: %w", err)
 			}
 
-			staticHandler.ServeHTTP(w, r)
-		})
+			if sp.id == "" {
+				mux.Handle("/", newMemFSHandler(memFS))
+				continue
+			}
+			prefix := "/" + sp.id
+			mux.Handle(prefix+"/", http.StripPrefix(prefix, newMemFSHandler(memFS)))
+			logger.Infof("Serving %s status page on %s", sp.id, siteURL)
+		}
+
+		// Workspaces have an index with all the status pages.
+		if c.workspaceFilePath != "" {
+			mux.Handle("/{$}", newWorkspaceIndexHandler(statusPages))
+		}
 
 		server := http.Server{
 			Addr:    c.listenAddress,
-			Handler: handler,
+			Handler: mux,
 		}
 
 		g.Add(
@@ -202,6 +158,45 @@ func (c *ServeCommand) Run(ctx context.Context) (err error) {
 	return g.Run()
 }
 
+// newMemFSHandler returns a handler that serves the status page generated in memory.
+func newMemFSHandler(memFS fstest.MapFS) http.Handler {
+	staticHandler := http.FileServerFS(memFS)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// If index we need to change index.html because of this: https://github.com/golang/go/blob/3d33437c450aa74014ea1d41cd986b6ee6266984/src/net/http/fs.go#L680
+		if r.URL.Path == "" || r.URL.Path == "/" {
+			r.URL.Path = "index"
+		}
+
+		// Same for directories that have an index (e.g: system details).
+		if _, err := fs.Stat(memFS, path.Join(strings.Trim(r.URL.Path, "/"), "index")); err == nil {
+			r.URL.Path = path.Join(r.URL.Path, "index")
+		}
+
+		staticHandler.ServeHTTP(w, r)
+	})
+}
+
+// newWorkspaceIndexHandler returns a handler with the links to all the workspace status pages.
+func newWorkspaceIndexHandler(statusPages []*statusPage) http.Handler {
+	links := ""
+	for _, sp := range statusPages {
+		links += fmt.Sprintf(`<li><a href="/%s/">%s</a></li>`, sp.id, html.EscapeString(sp.settings.Name))
+	}
+
+	index := []byte(`<html>
+		<head><title>Stactus workspace</title></head>
+		<body>
+		<h1>Status pages</h1>
+		<ul>` + links + `</ul>
+		</body>
+		</html>`)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(index)
+	})
+}
+
 type memFSFileManager struct {
 	fs fstest.MapFS
 }
@@ -15,10 +15,14 @@ import (
 	appgenerate "github.com/slok/stactus/internal/app/generate"
 	"github.com/slok/stactus/internal/conventions"
 	"github.com/slok/stactus/internal/storage"
+	"github.com/slok/stactus/internal/storage/badge"
 	"github.com/slok/stactus/internal/storage/feed"
 	htmlsimple "github.com/slok/stactus/internal/storage/html/themes/simple"
 	"github.com/slok/stactus/internal/storage/iofs"
+	"github.com/slok/stactus/internal/storage/jsondata"
+	"github.com/slok/stactus/internal/storage/ogimage"
 	"github.com/slok/stactus/internal/storage/prometheus"
+	"github.com/slok/stactus/internal/storage/widget"
 	utilfs "github.com/slok/stactus/internal/util/fs"
 )
 
@@ -154,8 +158,13 @@ func (c *ShowcaseGenerateCommand) Run(ctx context.Context) (err error) {
 					if err != nil {
 						return fmt.Errorf("incidents directory missing on at the same level of the stactus file: %w", err)
 					}
+					pagesFS, err := pagesSubFS(rootFS)
+					if err != nil {
+						return fmt.Errorf("could not load pages directory: %w", err)
+					}
 					roRepo, err := iofs.NewReadRepository(ctx, iofs.ReadRepositoryConfig{
 						IncidentsFS:     incidentsFS,
+						PagesFS:         pagesFS,
 						StactusFileData: string(stactusFileData),
 						Logger:          logger,
 					})
@@ -188,20 +197,53 @@ func (c *ShowcaseGenerateCommand) Run(ctx context.Context) (err error) {
 						}
 
 						repoFeedCreator, err := feed.NewFSRepository(feed.RepositoryConfig{
-							AtomHistoryFilePath: filepath.Join(outPath, conventions.IRHistoryAtomFeedPathName),
+							OutPath: outPath,
 						})
 						if err != nil {
 							return fmt.Errorf("could not create feed creator: %w", err)
 						}
 
+						repoBadgeCreator, err := badge.NewFSRepository(badge.RepositoryConfig{
+							OutPath: outPath,
+						})
+						if err != nil {
+							return fmt.Errorf("could not create badge creator: %w", err)
+						}
+
+						repoWidgetCreator, err := widget.NewFSRepository(widget.RepositoryConfig{
+							OutPath: outPath,
+						})
+						if err != nil {
+							return fmt.Errorf("could not create widget creator: %w", err)
+						}
+
+						repoDataCreator, err := jsondata.NewFSRepository(jsondata.RepositoryConfig{
+							OutPath: outPath,
+						})
+						if err != nil {
+							return fmt.Errorf("could not create data creator: %w", err)
+						}
+
+						repoOGImageCreator, err := ogimage.NewFSRepository(ogimage.RepositoryConfig{
+							OutPath: outPath,
+						})
+						if err != nil {
+							return fmt.Errorf("could not create OG image creator: %w", err)
+						}
+
 						// Generator service.
 						genService, err := appgenerate.NewService(appgenerate.ServiceConfig{
 							SettingsGetter:     roRepo,
 							SystemGetter:       roRepo,
 							IRGetter:           roRepo,
+							PageGetter:         roRepo,
 							UICreator:          uiCreator,
 							PromMetricsCreator: promRepo,
 							FeedCreator:        repoFeedCreator,
+							BadgeCreator:       repoBadgeCreator,
+							WidgetCreator:      repoWidgetCreator,
+							DataCreator:        repoDataCreator,
+							OGImageCreator:     repoOGImageCreator,
 							Logger:             logger,
 						})
 						if err != nil {
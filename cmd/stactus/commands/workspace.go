@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/slok/stactus/internal/log"
+	"github.com/slok/stactus/internal/storage/iofs"
+)
+
+// loadWorkspaceStatusPages loads all the status pages of a workspace file, the pages share the
+// workspace incidents filtered by their systems.
+func loadWorkspaceStatusPages(ctx context.Context, workspaceFilePath string, logger log.Logger) ([]*statusPage, error) {
+	workspaceFileData, err := os.ReadFile(workspaceFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not load workspace file: %w", err)
+	}
+
+	workspace, err := iofs.LoadWorkspace(string(workspaceFileData))
+	if err != nil {
+		return nil, fmt.Errorf("could not load workspace: %w", err)
+	}
+
+	// Paths are relative to the workspace file.
+	rootPath := filepath.Dir(workspaceFilePath)
+	incidentsPath := workspacePath(rootPath, workspace.IncidentsPath)
+	if _, err := os.Stat(incidentsPath); err != nil {
+		return nil, fmt.Errorf("workspace incidents directory missing: %w", err)
+	}
+	incidentsFS := os.DirFS(incidentsPath)
+
+	statusPages := []*statusPage{}
+	for _, p := range workspace.Pages {
+		sp, err := loadFSStatusPage(ctx, fsStatusPageConfig{
+			stactusFilePath: workspacePath(rootPath, p.StactusFilePath),
+			incidentsFS:     incidentsFS,
+			logger:          logger,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not load %s status page: %w", p.ID, err)
+		}
+		sp.id = p.ID
+		sp.outPath = p.OutPath
+
+		statusPages = append(statusPages, sp)
+	}
+
+	return statusPages, nil
+}
+
+// workspacePath returns the path relative to the workspace root path, absolute paths are kept.
+func workspacePath(rootPath, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(rootPath, path)
+}
@@ -32,6 +32,7 @@ func Run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.
 	serveCmd := commands.NewServeCommand(rootCmd, app)
 	migrateCmd := commands.NewMigrateCommand(app)
 	migrateStatusPageCmd := commands.NewMigrateStatusPageCommand(rootCmd, migrateCmd)
+	schemaCmd := commands.NewSchemaCommand(rootCmd, app)
 	versionCmd := commands.NewVersionCommand(rootCmd, app)
 
 	cmds := map[string]commands.Command{
@@ -42,6 +43,7 @@ func Run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.
 		serveCmd.Name():             serveCmd,
 		migrateCmd.Name():           migrateCmd,
 		migrateStatusPageCmd.Name(): migrateStatusPageCmd,
+		schemaCmd.Name():            schemaCmd,
 		versionCmd.Name():           versionCmd,
 	}
 
@@ -3,6 +3,7 @@ package generate
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/slok/stactus/internal/internalerrors"
@@ -15,9 +16,14 @@ type ServiceConfig struct {
 	SettingsGetter     storage.StatusPageSettingsGetter
 	SystemGetter       storage.SystemGetter
 	IRGetter           storage.IncidentReportGetter
+	PageGetter         storage.PageGetter
 	UICreator          storage.UICreator
 	PromMetricsCreator storage.PromMetricsCreator
 	FeedCreator        storage.FeedCreator
+	BadgeCreator       storage.BadgeCreator
+	WidgetCreator      storage.WidgetCreator
+	DataCreator        storage.DataCreator
+	OGImageCreator     storage.OGImageCreator
 
 	Logger log.Logger
 }
@@ -35,6 +41,10 @@ func (c *ServiceConfig) defaults() error {
 		return fmt.Errorf("ir getter is required")
 	}
 
+	if c.PageGetter == nil {
+		return fmt.Errorf("page getter is required")
+	}
+
 	if c.UICreator == nil {
 		return fmt.Errorf("ui creator is required")
 	}
@@ -47,6 +57,22 @@ func (c *ServiceConfig) defaults() error {
 		return fmt.Errorf("feed creator is required")
 	}
 
+	if c.BadgeCreator == nil {
+		return fmt.Errorf("badge creator is required")
+	}
+
+	if c.WidgetCreator == nil {
+		return fmt.Errorf("widget creator is required")
+	}
+
+	if c.DataCreator == nil {
+		return fmt.Errorf("data creator is required")
+	}
+
+	if c.OGImageCreator == nil {
+		return fmt.Errorf("OG image creator is required")
+	}
+
 	if c.Logger == nil {
 		return fmt.Errorf("logger is required")
 	}
@@ -60,9 +86,14 @@ type Service struct {
 	settingsGetter storage.StatusPageSettingsGetter
 	sysGetter      storage.SystemGetter
 	irGetter       storage.IncidentReportGetter
+	pageGetter     storage.PageGetter
 	uiCreator      storage.UICreator
 	promCreator    storage.PromMetricsCreator
 	feedCreator    storage.FeedCreator
+	badgeCreator   storage.BadgeCreator
+	widgetCreator  storage.WidgetCreator
+	dataCreator    storage.DataCreator
+	ogImageCreator storage.OGImageCreator
 	logger         log.Logger
 }
 
@@ -76,18 +107,37 @@ func NewService(config ServiceConfig) (*Service, error) {
 		settingsGetter: config.SettingsGetter,
 		sysGetter:      config.SystemGetter,
 		irGetter:       config.IRGetter,
+		pageGetter:     config.PageGetter,
 		uiCreator:      config.UICreator,
 		promCreator:    config.PromMetricsCreator,
 		feedCreator:    config.FeedCreator,
+		badgeCreator:   config.BadgeCreator,
+		widgetCreator:  config.WidgetCreator,
+		dataCreator:    config.DataCreator,
+		ogImageCreator: config.OGImageCreator,
 		logger:         config.Logger,
 	}, nil
 }
 
 type GenerateReq struct {
 	OverrideSiteURL string
+	// Audience of the status page, only the incidents visible to the audience will be
+	// generated (by default public).
+	Audience model.Audience
+	// Preview will generate the draft incidents too (e.g: serving the status page locally).
+	Preview bool
 }
 
 func (r *GenerateReq) validate() error {
+	if r.Audience == "" {
+		r.Audience = model.AudiencePublic
+	}
+
+	err := r.Audience.Validate()
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -106,26 +156,57 @@ func (s Service) Generate(ctx context.Context, req GenerateReq) (GenerateResp, e
 	if err != nil {
 		return GenerateResp{}, fmt.Errorf("could not get site settings: %w", err)
 	}
-	if req.OverrideSiteURL != "" {
-		settings.URL = req.OverrideSiteURL
+	settings.URL = settings.AudienceURL(req.Audience)
+	if overrideURL := strings.TrimSuffix(strings.TrimSpace(req.OverrideSiteURL), "/"); overrideURL != "" {
+		settings.URL = overrideURL
 	}
 
 	// Get all systems.
-	systems, err := s.sysGetter.ListAllSystems(ctx)
+	allSystems, err := s.sysGetter.ListAllSystems(ctx)
 	if err != nil {
 		return GenerateResp{}, fmt.Errorf("could not list systems: %w", err)
 	}
 
+	systems := []model.System{}
+	visibleSystemIDs := map[string]bool{}
+	for _, s := range allSystems {
+		if !s.VisibleTo(req.Audience) {
+			continue
+		}
+		systems = append(systems, s)
+		visibleSystemIDs[s.ID] = true
+	}
+
 	// Get all IRs.
 	irs, err := s.irGetter.ListAllIncidentReports(ctx)
 	if err != nil {
 		return GenerateResp{}, fmt.Errorf("could not list IRs: %w", err)
 	}
 
+	// Get all custom pages.
+	pages, err := s.pageGetter.ListAllPages(ctx)
+	if err != nil {
+		return GenerateResp{}, fmt.Errorf("could not list pages: %w", err)
+	}
+
 	// Prepare data.
+	// Only the visible incidents are used, so all the outputs (HTML, feeds, metrics, data...) are consistent.
 	history := []*model.IncidentReport{}
 	for _, ir := range irs {
-		history = append(history, &ir)
+		if !ir.VisibleTo(req.Audience, req.Preview) {
+			continue
+		}
+
+		// Incidents that only affect systems, or only have updates, the audience can't see are hidden.
+		audienceIR := ir.ForAudience(req.Audience, visibleSystemIDs)
+		if len(audienceIR.SystemIDs) == 0 && len(ir.SystemIDs) > 0 {
+			continue
+		}
+		if len(audienceIR.Timeline) == 0 && len(ir.Timeline) > 0 {
+			continue
+		}
+
+		history = append(history, &audienceIR)
 	}
 
 	openedIRs := []*model.IncidentReport{}
@@ -143,13 +224,20 @@ func (s Service) Generate(ctx context.Context, req GenerateReq) (GenerateResp, e
 	systemDetails := []model.SystemDetails{}
 	for _, s := range systems {
 		var latestIR *model.IncidentReport
-		if len(irsBySystem[s.ID]) > 0 {
-			latestIR = irsBySystem[s.ID][0]
+		for _, ir := range irsBySystem[s.ID] {
+			if latestIR == nil || ir.Start.After(latestIR.Start) {
+				latestIR = ir
+			}
 		}
 		systemDetails = append(systemDetails, model.SystemDetails{
 			System:   s,
 			LatestIR: latestIR,
 			IRs:      irsBySystem[s.ID],
+			Status:   calcSystemStatus(irsBySystem[s.ID]),
+			Stats: model.SystemStats{
+				TotalIRs: len(irsBySystem[s.ID]),
+				MTTR:     calcMTTR(irsBySystem[s.ID]),
+			},
 		})
 	}
 
@@ -159,8 +247,6 @@ func (s Service) Generate(ctx context.Context, req GenerateReq) (GenerateResp, e
 		TotalIRs:     len(history),
 		TotalOpenIRs: len(openedIRs),
 	}
-	var mttrTotalTime time.Duration
-	mttrTotalIRs := 0
 	for _, ir := range history {
 		switch ir.Impact {
 		case model.IncidentImpactMinor:
@@ -170,25 +256,18 @@ func (s Service) Generate(ctx context.Context, req GenerateReq) (GenerateResp, e
 		case model.IncidentImpactCritical:
 			stats.TotalCriticalIRs++
 		}
-
-		if ir.Duration != 0 {
-			mttrTotalIRs++
-			mttrTotalTime += ir.Duration
-		}
-	}
-
-	if mttrTotalTime != 0 {
-		mttr := mttrTotalTime / time.Duration(mttrTotalIRs)
-		stats.MTTR = mttr
 	}
+	stats.MTTR = calcMTTR(history)
 
 	// Generate UI.
 	ui := model.UI{
+		Status:        calcSystemStatus(history),
 		Settings:      *settings,
 		Stats:         stats,
 		SystemDetails: systemDetails,
 		History:       history,
 		OpenedIRs:     openedIRs,
+		Pages:         pages,
 	}
 	err = s.uiCreator.CreateUI(ctx, ui)
 	if err != nil {
@@ -207,5 +286,73 @@ func (s Service) Generate(ctx context.Context, req GenerateReq) (GenerateResp, e
 		return GenerateResp{}, fmt.Errorf("could not generate feeds: %w", err)
 	}
 
+	// Generate badges.
+	err = s.badgeCreator.CreateBadges(ctx, ui)
+	if err != nil {
+		return GenerateResp{}, fmt.Errorf("could not generate badges: %w", err)
+	}
+
+	// Generate widget.
+	err = s.widgetCreator.CreateWidget(ctx, ui)
+	if err != nil {
+		return GenerateResp{}, fmt.Errorf("could not generate widget: %w", err)
+	}
+
+	// Generate JSON data.
+	err = s.dataCreator.CreateData(ctx, ui)
+	if err != nil {
+		return GenerateResp{}, fmt.Errorf("could not generate data: %w", err)
+	}
+
+	// Generate OpenGraph preview images.
+	err = s.ogImageCreator.CreateOGImages(ctx, ui)
+	if err != nil {
+		return GenerateResp{}, fmt.Errorf("could not generate OpenGraph images: %w", err)
+	}
+
 	return GenerateResp{}, nil
 }
+
+// calcSystemStatus calculates the current status of a system based on all its open incidents,
+// the impact will be the worst of them.
+func calcSystemStatus(irs []*model.IncidentReport) model.SystemStatus {
+	status := model.SystemStatus{
+		Operational: true,
+		Impact:      model.IncidentImpactNone,
+	}
+
+	for _, ir := range irs {
+		if !ir.End.IsZero() {
+			continue
+		}
+
+		// Open incidents without impact are informational, they don't degrade the system.
+		status.OpenIRIDs = append(status.OpenIRIDs, ir.ID)
+		if ir.Impact.Level() > model.IncidentImpactNone.Level() {
+			status.Operational = false
+		}
+		if ir.Impact.Level() > status.Impact.Level() {
+			status.Impact = ir.Impact
+		}
+	}
+
+	return status
+}
+
+// calcMTTR calculates the mean time to recovery of the resolved incidents.
+func calcMTTR(irs []*model.IncidentReport) time.Duration {
+	var mttrTotalTime time.Duration
+	mttrTotalIRs := 0
+	for _, ir := range irs {
+		if ir.Duration != 0 {
+			mttrTotalIRs++
+			mttrTotalTime += ir.Duration
+		}
+	}
+
+	if mttrTotalTime == 0 {
+		return 0
+	}
+
+	return mttrTotalTime / time.Duration(mttrTotalIRs)
+}
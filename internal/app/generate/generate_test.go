@@ -21,13 +21,54 @@ func TestGenerate(t *testing.T) {
 		mstg *storagemock.StatusPageSettingsGetter
 		msg  *storagemock.SystemGetter
 		mig  *storagemock.IncidentReportGetter
+		mpg  *storagemock.PageGetter
 		muc  *storagemock.UICreator
 		mpc  *storagemock.PromMetricsCreator
 		mfc  *storagemock.FeedCreator
+		mbc  *storagemock.BadgeCreator
+		mwc  *storagemock.WidgetCreator
+		mdc  *storagemock.DataCreator
+		moc  *storagemock.OGImageCreator
 	}
 
 	t0 := time.Now()
 
+	// Visibility test incidents.
+	irPublic := model.IncidentReport{ID: "ir1", Visibility: model.IncidentVisibilityPublic, Impact: model.IncidentImpactMinor, Start: t0, End: t0.Add(time.Hour), Duration: time.Hour}
+	irDraft := model.IncidentReport{ID: "ir2", Visibility: model.IncidentVisibilityDraft, Impact: model.IncidentImpactMinor, Start: t0, End: t0.Add(time.Hour), Duration: time.Hour}
+	irInternal := model.IncidentReport{ID: "ir3", Visibility: model.IncidentVisibilityInternal, Impact: model.IncidentImpactMinor, Start: t0, End: t0.Add(time.Hour), Duration: time.Hour}
+	mockVisibility := func(m mocks, expIRs ...model.IncidentReport) {
+		m.mstg.On("GetStatusPageSettings", mock.Anything).Once().Return(&model.StatusPageSettings{Name: "test1", URL: "https://test.io"}, nil)
+		m.msg.On("ListAllSystems", mock.Anything).Once().Return([]model.System{}, nil)
+		m.mig.On("ListAllIncidentReports", mock.Anything).Return([]model.IncidentReport{irPublic, irDraft, irInternal}, nil)
+		m.mpg.On("ListAllPages", mock.Anything).Return([]model.Page{}, nil)
+
+		history := []*model.IncidentReport{}
+		for _, ir := range expIRs {
+			history = append(history, &ir)
+		}
+		exp := model.UI{
+			Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
+			Stats: model.UIStats{
+				TotalIRs:      len(expIRs),
+				TotalMinorIRs: len(expIRs),
+				MTTR:          time.Hour,
+			},
+			Settings:      model.StatusPageSettings{Name: "test1", URL: "https://test.io"},
+			Pages:         []model.Page{},
+			OpenedIRs:     []*model.IncidentReport{},
+			History:       history,
+			SystemDetails: []model.SystemDetails{},
+		}
+		m.muc.On("CreateUI", mock.Anything, exp).Once().Return(nil)
+		m.mpc.On("CreatePromMetrics", mock.Anything, exp).Once().Return(nil)
+		m.mfc.On("CreateHistoryFeed", mock.Anything, exp).Once().Return(nil)
+		m.mbc.On("CreateBadges", mock.Anything, exp).Once().Return(nil)
+		m.mwc.On("CreateWidget", mock.Anything, exp).Once().Return(nil)
+		m.mdc.On("CreateData", mock.Anything, exp).Once().Return(nil)
+		m.moc.On("CreateOGImages", mock.Anything, exp).Once().Return(nil)
+	}
+
 	tests := map[string]struct {
 		mock    func(m mocks)
 		req     generate.GenerateReq
@@ -65,11 +106,24 @@ func TestGenerate(t *testing.T) {
 			expErr:  true,
 		},
 
+		"If listing pages returns an error, it should fail.": {
+			mock: func(m mocks) {
+				m.mstg.On("GetStatusPageSettings", mock.Anything).Once().Return(&model.StatusPageSettings{Name: "test1", URL: "https://test.io"}, nil)
+				m.msg.On("ListAllSystems", mock.Anything).Once().Return([]model.System{}, nil)
+				m.mig.On("ListAllIncidentReports", mock.Anything).Return([]model.IncidentReport{}, nil)
+				m.mpg.On("ListAllPages", mock.Anything).Return(nil, fmt.Errorf("something"))
+			},
+			req:     generate.GenerateReq{},
+			expResp: generate.GenerateResp{},
+			expErr:  true,
+		},
+
 		"If UI generation returns an error, it should fail.": {
 			mock: func(m mocks) {
 				m.mstg.On("GetStatusPageSettings", mock.Anything).Once().Return(&model.StatusPageSettings{Name: "test1", URL: "https://test.io"}, nil)
 				m.msg.On("ListAllSystems", mock.Anything).Once().Return([]model.System{}, nil)
 				m.mig.On("ListAllIncidentReports", mock.Anything).Return([]model.IncidentReport{}, nil)
+				m.mpg.On("ListAllPages", mock.Anything).Return([]model.Page{}, nil)
 				m.muc.On("CreateUI", mock.Anything, mock.Anything).Once().Return(fmt.Errorf("something"))
 			},
 			req:     generate.GenerateReq{},
@@ -87,8 +141,10 @@ func TestGenerate(t *testing.T) {
 				}, nil)
 
 				m.mig.On("ListAllIncidentReports", mock.Anything).Return([]model.IncidentReport{}, nil)
+				m.mpg.On("ListAllPages", mock.Anything).Return([]model.Page{}, nil)
 
 				exp := model.UI{
+					Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
 					Stats: model.UIStats{
 						TotalSystems: 3,
 					},
@@ -98,15 +154,19 @@ func TestGenerate(t *testing.T) {
 					},
 					OpenedIRs: []*model.IncidentReport{},
 					History:   []*model.IncidentReport{},
+					Pages:     []model.Page{},
 					SystemDetails: []model.SystemDetails{
 						{
 							System: model.System{ID: "test1", Name: "Test 1", Description: "Something 1"},
+							Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
 						},
 						{
 							System: model.System{ID: "test2", Name: "Test 2", Description: "Something 2"},
+							Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
 						},
 						{
 							System: model.System{ID: "test3", Name: "Test 3", Description: "Something 3"},
+							Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
 						},
 					},
 				}
@@ -115,6 +175,14 @@ func TestGenerate(t *testing.T) {
 				m.mpc.On("CreatePromMetrics", mock.Anything, exp).Once().Return(nil)
 
 				m.mfc.On("CreateHistoryFeed", mock.Anything, exp).Once().Return(nil)
+
+				m.mbc.On("CreateBadges", mock.Anything, exp).Once().Return(nil)
+
+				m.mwc.On("CreateWidget", mock.Anything, exp).Once().Return(nil)
+
+				m.mdc.On("CreateData", mock.Anything, exp).Once().Return(nil)
+
+				m.moc.On("CreateOGImages", mock.Anything, exp).Once().Return(nil)
 			},
 			req:     generate.GenerateReq{},
 			expResp: generate.GenerateResp{},
@@ -134,6 +202,7 @@ func TestGenerate(t *testing.T) {
 						ID:        "ir1",
 						SystemIDs: []string{"test2"},
 						Name:      "IR 1",
+						Impact:    model.IncidentImpactMinor,
 						Start:     t0,
 						Timeline: []model.IncidentReportEvent{
 							{Description: "desc1"},
@@ -157,40 +226,54 @@ func TestGenerate(t *testing.T) {
 					},
 				}, nil)
 
+				m.mpg.On("ListAllPages", mock.Anything).Return([]model.Page{
+					{Slug: "about", Title: "About", NavOrder: 1, Content: "Something"},
+				}, nil)
+
 				exp := model.UI{
+					Status: model.SystemStatus{Impact: model.IncidentImpactMinor, OpenIRIDs: []string{"ir1"}},
 					Stats: model.UIStats{
-						TotalSystems: 3,
-						TotalOpenIRs: 1,
-						TotalIRs:     3,
-						MTTR:         210 * time.Minute,
+						TotalSystems:  3,
+						TotalOpenIRs:  1,
+						TotalIRs:      3,
+						TotalMinorIRs: 1,
+						MTTR:          210 * time.Minute,
 					},
 					Settings: model.StatusPageSettings{
 						Name: "test1",
 						URL:  "https://something-new.io",
 					},
+					Pages: []model.Page{
+						{Slug: "about", Title: "About", NavOrder: 1, Content: "Something"},
+					},
 					OpenedIRs: []*model.IncidentReport{
-						{ID: "ir1", SystemIDs: []string{"test2"}, Name: "IR 1", Start: t0, Timeline: []model.IncidentReportEvent{{Description: "desc1"}}},
+						{ID: "ir1", SystemIDs: []string{"test2"}, Name: "IR 1", Impact: model.IncidentImpactMinor, Start: t0, Timeline: []model.IncidentReportEvent{{Description: "desc1"}}},
 					},
 					History: []*model.IncidentReport{
-						{ID: "ir1", SystemIDs: []string{"test2"}, Name: "IR 1", Start: t0, Timeline: []model.IncidentReportEvent{{Description: "desc1"}}},
+						{ID: "ir1", SystemIDs: []string{"test2"}, Name: "IR 1", Impact: model.IncidentImpactMinor, Start: t0, Timeline: []model.IncidentReportEvent{{Description: "desc1"}}},
 						{ID: "ir3", SystemIDs: []string{"test3"}, Name: "IR 3", Duration: 1 * time.Hour, Start: t0.Add(-3 * time.Hour), End: t0.Add(-2 * time.Hour)},
 						{ID: "ir2", SystemIDs: []string{"test2", "test3"}, Name: "IR 2", Duration: 6 * time.Hour, Start: t0.Add(-10 * time.Hour), End: t0.Add(-4 * time.Hour)},
 					},
 					SystemDetails: []model.SystemDetails{
 						{
 							System: model.System{ID: "test1", Name: "Test 1", Description: "Something 1"},
+							Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
 						},
 						{
 							System:   model.System{ID: "test2", Name: "Test 2", Description: "Something 2"},
-							LatestIR: &model.IncidentReport{ID: "ir1", SystemIDs: []string{"test2"}, Name: "IR 1", Start: t0, Timeline: []model.IncidentReportEvent{{Description: "desc1"}}},
+							LatestIR: &model.IncidentReport{ID: "ir1", SystemIDs: []string{"test2"}, Name: "IR 1", Impact: model.IncidentImpactMinor, Start: t0, Timeline: []model.IncidentReportEvent{{Description: "desc1"}}},
+							Status:   model.SystemStatus{Impact: model.IncidentImpactMinor, OpenIRIDs: []string{"ir1"}},
+							Stats:    model.SystemStats{TotalIRs: 2, MTTR: 6 * time.Hour},
 							IRs: []*model.IncidentReport{
-								{ID: "ir1", SystemIDs: []string{"test2"}, Name: "IR 1", Start: t0, Timeline: []model.IncidentReportEvent{{Description: "desc1"}}},
+								{ID: "ir1", SystemIDs: []string{"test2"}, Name: "IR 1", Impact: model.IncidentImpactMinor, Start: t0, Timeline: []model.IncidentReportEvent{{Description: "desc1"}}},
 								{ID: "ir2", SystemIDs: []string{"test2", "test3"}, Name: "IR 2", Duration: 6 * time.Hour, Start: t0.Add(-10 * time.Hour), End: t0.Add(-4 * time.Hour)},
 							},
 						},
 						{
 							System:   model.System{ID: "test3", Name: "Test 3", Description: "Something 3"},
 							LatestIR: &model.IncidentReport{ID: "ir3", SystemIDs: []string{"test3"}, Name: "IR 3", Duration: 1 * time.Hour, Start: t0.Add(-3 * time.Hour), End: t0.Add(-2 * time.Hour)},
+							Status:   model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
+							Stats:    model.SystemStats{TotalIRs: 2, MTTR: 210 * time.Minute},
 							IRs: []*model.IncidentReport{
 								{ID: "ir3", SystemIDs: []string{"test3"}, Name: "IR 3", Duration: 1 * time.Hour, Start: t0.Add(-3 * time.Hour), End: t0.Add(-2 * time.Hour)},
 								{ID: "ir2", SystemIDs: []string{"test2", "test3"}, Name: "IR 2", Duration: 6 * time.Hour, Start: t0.Add(-10 * time.Hour), End: t0.Add(-4 * time.Hour)},
@@ -203,8 +286,237 @@ func TestGenerate(t *testing.T) {
 				m.mpc.On("CreatePromMetrics", mock.Anything, exp).Once().Return(nil)
 
 				m.mfc.On("CreateHistoryFeed", mock.Anything, exp).Once().Return(nil)
+
+				m.mbc.On("CreateBadges", mock.Anything, exp).Once().Return(nil)
+
+				m.mwc.On("CreateWidget", mock.Anything, exp).Once().Return(nil)
+
+				m.mdc.On("CreateData", mock.Anything, exp).Once().Return(nil)
+
+				m.moc.On("CreateOGImages", mock.Anything, exp).Once().Return(nil)
+			},
+			req:     generate.GenerateReq{OverrideSiteURL: " https://something-new.io/ "},
+			expResp: generate.GenerateResp{},
+		},
+
+		"Overlapping incidents should compute the system status with all the open incidents.": {
+			mock: func(m mocks) {
+				m.mstg.On("GetStatusPageSettings", mock.Anything).Once().Return(&model.StatusPageSettings{Name: "test1", URL: "https://test.io"}, nil)
+				m.msg.On("ListAllSystems", mock.Anything).Once().Return([]model.System{
+					{ID: "test1", Name: "Test 1", Description: "Something 1"},
+				}, nil)
+
+				// Unsorted on purpose.
+				m.mig.On("ListAllIncidentReports", mock.Anything).Return([]model.IncidentReport{
+					{ID: "ir1", SystemIDs: []string{"test1"}, Impact: model.IncidentImpactCritical, Start: t0.Add(-2 * time.Hour)},
+					{ID: "ir2", SystemIDs: []string{"test1"}, Impact: model.IncidentImpactCritical, Start: t0, End: t0.Add(1 * time.Hour), Duration: 1 * time.Hour},
+					{ID: "ir3", SystemIDs: []string{"test1"}, Impact: model.IncidentImpactMinor, Start: t0.Add(-1 * time.Hour)},
+				}, nil)
+				m.mpg.On("ListAllPages", mock.Anything).Return([]model.Page{}, nil)
+
+				ir1 := &model.IncidentReport{ID: "ir1", SystemIDs: []string{"test1"}, Impact: model.IncidentImpactCritical, Start: t0.Add(-2 * time.Hour)}
+				ir2 := &model.IncidentReport{ID: "ir2", SystemIDs: []string{"test1"}, Impact: model.IncidentImpactCritical, Start: t0, End: t0.Add(1 * time.Hour), Duration: 1 * time.Hour}
+				ir3 := &model.IncidentReport{ID: "ir3", SystemIDs: []string{"test1"}, Impact: model.IncidentImpactMinor, Start: t0.Add(-1 * time.Hour)}
+				exp := model.UI{
+					Status: model.SystemStatus{Impact: model.IncidentImpactCritical, OpenIRIDs: []string{"ir1", "ir3"}},
+					Stats: model.UIStats{
+						TotalSystems:     1,
+						TotalIRs:         3,
+						TotalOpenIRs:     2,
+						TotalMinorIRs:    1,
+						TotalCriticalIRs: 2,
+						MTTR:             1 * time.Hour,
+					},
+					Settings:  model.StatusPageSettings{Name: "test1", URL: "https://test.io"},
+					Pages:     []model.Page{},
+					OpenedIRs: []*model.IncidentReport{ir1, ir3},
+					History:   []*model.IncidentReport{ir1, ir2, ir3},
+					SystemDetails: []model.SystemDetails{
+						{
+							System:   model.System{ID: "test1", Name: "Test 1", Description: "Something 1"},
+							LatestIR: ir2,
+							IRs:      []*model.IncidentReport{ir1, ir2, ir3},
+							Status:   model.SystemStatus{Impact: model.IncidentImpactCritical, OpenIRIDs: []string{"ir1", "ir3"}},
+							Stats:    model.SystemStats{TotalIRs: 3, MTTR: 1 * time.Hour},
+						},
+					},
+				}
+				m.muc.On("CreateUI", mock.Anything, exp).Once().Return(nil)
+
+				m.mpc.On("CreatePromMetrics", mock.Anything, exp).Once().Return(nil)
+
+				m.mfc.On("CreateHistoryFeed", mock.Anything, exp).Once().Return(nil)
+
+				m.mbc.On("CreateBadges", mock.Anything, exp).Once().Return(nil)
+
+				m.mwc.On("CreateWidget", mock.Anything, exp).Once().Return(nil)
+
+				m.mdc.On("CreateData", mock.Anything, exp).Once().Return(nil)
+
+				m.moc.On("CreateOGImages", mock.Anything, exp).Once().Return(nil)
+			},
+			req:     generate.GenerateReq{},
+			expResp: generate.GenerateResp{},
+		},
+
+		"Open incidents without impact should not degrade the system status.": {
+			mock: func(m mocks) {
+				m.mstg.On("GetStatusPageSettings", mock.Anything).Once().Return(&model.StatusPageSettings{Name: "test1", URL: "https://test.io"}, nil)
+				m.msg.On("ListAllSystems", mock.Anything).Once().Return([]model.System{
+					{ID: "test1", Name: "Test 1", Description: "Something 1"},
+					{ID: "test2", Name: "Test 2", Description: "Something 2"},
+				}, nil)
+				m.mig.On("ListAllIncidentReports", mock.Anything).Return([]model.IncidentReport{
+					{ID: "ir1", SystemIDs: []string{"test1"}, Impact: model.IncidentImpactNone, Start: t0},
+					{ID: "ir2", SystemIDs: []string{"test2"}, Impact: model.IncidentImpactNone, Start: t0.Add(-1 * time.Hour)},
+					{ID: "ir3", SystemIDs: []string{"test2"}, Impact: model.IncidentImpactMajor, Start: t0.Add(-2 * time.Hour)},
+				}, nil)
+				m.mpg.On("ListAllPages", mock.Anything).Return([]model.Page{}, nil)
+
+				ir1 := &model.IncidentReport{ID: "ir1", SystemIDs: []string{"test1"}, Impact: model.IncidentImpactNone, Start: t0}
+				ir2 := &model.IncidentReport{ID: "ir2", SystemIDs: []string{"test2"}, Impact: model.IncidentImpactNone, Start: t0.Add(-1 * time.Hour)}
+				ir3 := &model.IncidentReport{ID: "ir3", SystemIDs: []string{"test2"}, Impact: model.IncidentImpactMajor, Start: t0.Add(-2 * time.Hour)}
+				exp := model.UI{
+					Status: model.SystemStatus{Impact: model.IncidentImpactMajor, OpenIRIDs: []string{"ir1", "ir2", "ir3"}},
+					Stats: model.UIStats{
+						TotalSystems:  2,
+						TotalIRs:      3,
+						TotalOpenIRs:  3,
+						TotalMajorIRs: 1,
+					},
+					Settings:  model.StatusPageSettings{Name: "test1", URL: "https://test.io"},
+					Pages:     []model.Page{},
+					OpenedIRs: []*model.IncidentReport{ir1, ir2, ir3},
+					History:   []*model.IncidentReport{ir1, ir2, ir3},
+					SystemDetails: []model.SystemDetails{
+						{
+							System:   model.System{ID: "test1", Name: "Test 1", Description: "Something 1"},
+							LatestIR: ir1,
+							IRs:      []*model.IncidentReport{ir1},
+							Status:   model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone, OpenIRIDs: []string{"ir1"}},
+							Stats:    model.SystemStats{TotalIRs: 1},
+						},
+						{
+							System:   model.System{ID: "test2", Name: "Test 2", Description: "Something 2"},
+							LatestIR: ir2,
+							IRs:      []*model.IncidentReport{ir2, ir3},
+							Status:   model.SystemStatus{Impact: model.IncidentImpactMajor, OpenIRIDs: []string{"ir2", "ir3"}},
+							Stats:    model.SystemStats{TotalIRs: 2},
+						},
+					},
+				}
+				m.muc.On("CreateUI", mock.Anything, exp).Once().Return(nil)
+
+				m.mpc.On("CreatePromMetrics", mock.Anything, exp).Once().Return(nil)
+
+				m.mfc.On("CreateHistoryFeed", mock.Anything, exp).Once().Return(nil)
+
+				m.mbc.On("CreateBadges", mock.Anything, exp).Once().Return(nil)
+
+				m.mwc.On("CreateWidget", mock.Anything, exp).Once().Return(nil)
+
+				m.mdc.On("CreateData", mock.Anything, exp).Once().Return(nil)
+
+				m.moc.On("CreateOGImages", mock.Anything, exp).Once().Return(nil)
+			},
+			req:     generate.GenerateReq{},
+			expResp: generate.GenerateResp{},
+		},
+
+		"An unknown audience should fail.": {
+			mock:    func(m mocks) {},
+			req:     generate.GenerateReq{Audience: "everyone"},
+			expResp: generate.GenerateResp{Message: `unknown audience "everyone"`},
+			expErr:  true,
+		},
+
+		"Public builds should not have the draft and internal incidents.": {
+			mock: func(m mocks) {
+				mockVisibility(m, irPublic)
+			},
+			req:     generate.GenerateReq{},
+			expResp: generate.GenerateResp{},
+		},
+
+		"Internal builds should have the internal incidents.": {
+			mock: func(m mocks) {
+				mockVisibility(m, irPublic, irInternal)
+			},
+			req:     generate.GenerateReq{Audience: model.AudienceInternal},
+			expResp: generate.GenerateResp{},
+		},
+
+		"Audiences should only have their systems, incidents, updates and site URL.": {
+			mock: func(m mocks) {
+				settings := model.StatusPageSettings{Name: "test1", URL: "https://test.io", Audiences: []model.AudienceSettings{
+					{Audience: model.AudiencePublic, URL: "https://public.test.io"},
+					{Audience: model.AudienceInternal, URL: "https://internal.test.io"},
+				}}
+				m.mstg.On("GetStatusPageSettings", mock.Anything).Once().Return(&settings, nil)
+				m.msg.On("ListAllSystems", mock.Anything).Once().Return([]model.System{
+					{ID: "test1", Name: "Test 1"},
+					{ID: "test2", Name: "Test 2", Audience: model.AudienceInternal},
+				}, nil)
+				m.mig.On("ListAllIncidentReports", mock.Anything).Return([]model.IncidentReport{
+					{ID: "ir1", SystemIDs: []string{"test1", "test2"}, Impact: model.IncidentImpactMinor, Start: t0, End: t0.Add(time.Hour), Duration: time.Hour, Timeline: []model.IncidentReportEvent{
+						{Description: "Fixed", Kind: model.IncidentUpdateKindResolved, TS: t0.Add(time.Hour)},
+						{Description: "Paged", TS: t0.Add(time.Minute), Audience: model.AudienceInternal},
+						{Description: "Looking", TS: t0, PrivateNote: "It's the DB"},
+					}},
+					{ID: "ir2", SystemIDs: []string{"test2"}, Impact: model.IncidentImpactMinor, Start: t0, Timeline: []model.IncidentReportEvent{
+						{Description: "Internal system", TS: t0},
+					}},
+					{ID: "ir3", SystemIDs: []string{"test1"}, Impact: model.IncidentImpactMinor, Start: t0, Timeline: []model.IncidentReportEvent{
+						{Description: "Internal update", TS: t0, Audience: model.AudienceInternal},
+					}},
+				}, nil)
+				m.mpg.On("ListAllPages", mock.Anything).Return([]model.Page{}, nil)
+
+				ir1 := &model.IncidentReport{ID: "ir1", SystemIDs: []string{"test1"}, Impact: model.IncidentImpactMinor, Start: t0, End: t0.Add(time.Hour), Duration: time.Hour, Timeline: []model.IncidentReportEvent{
+					{Description: "Fixed", Kind: model.IncidentUpdateKindResolved, TS: t0.Add(time.Hour)},
+					{Description: "Looking", TS: t0},
+				}}
+				expSettings := settings
+				expSettings.URL = "https://public.test.io"
+				exp := model.UI{
+					Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
+					Stats: model.UIStats{
+						TotalSystems:  1,
+						TotalIRs:      1,
+						TotalMinorIRs: 1,
+						MTTR:          time.Hour,
+					},
+					Settings:  expSettings,
+					Pages:     []model.Page{},
+					OpenedIRs: []*model.IncidentReport{},
+					History:   []*model.IncidentReport{ir1},
+					SystemDetails: []model.SystemDetails{
+						{
+							System:   model.System{ID: "test1", Name: "Test 1"},
+							LatestIR: ir1,
+							IRs:      []*model.IncidentReport{ir1},
+							Status:   model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
+							Stats:    model.SystemStats{TotalIRs: 1, MTTR: time.Hour},
+						},
+					},
+				}
+				m.muc.On("CreateUI", mock.Anything, exp).Once().Return(nil)
+				m.mpc.On("CreatePromMetrics", mock.Anything, exp).Once().Return(nil)
+				m.mfc.On("CreateHistoryFeed", mock.Anything, exp).Once().Return(nil)
+				m.mbc.On("CreateBadges", mock.Anything, exp).Once().Return(nil)
+				m.mwc.On("CreateWidget", mock.Anything, exp).Once().Return(nil)
+				m.mdc.On("CreateData", mock.Anything, exp).Once().Return(nil)
+				m.moc.On("CreateOGImages", mock.Anything, exp).Once().Return(nil)
+			},
+			req:     generate.GenerateReq{Audience: model.AudiencePublic},
+			expResp: generate.GenerateResp{},
+		},
+
+		"Previews should have the draft incidents.": {
+			mock: func(m mocks) {
+				mockVisibility(m, irPublic, irDraft)
 			},
-			req:     generate.GenerateReq{OverrideSiteURL: "https://something-new.io"},
+			req:     generate.GenerateReq{Preview: true},
 			expResp: generate.GenerateResp{},
 		},
 	}
@@ -219,9 +531,14 @@ func TestGenerate(t *testing.T) {
 				mstg: storagemock.NewStatusPageSettingsGetter(t),
 				msg:  storagemock.NewSystemGetter(t),
 				mig:  storagemock.NewIncidentReportGetter(t),
+				mpg:  storagemock.NewPageGetter(t),
 				muc:  storagemock.NewUICreator(t),
 				mpc:  storagemock.NewPromMetricsCreator(t),
 				mfc:  storagemock.NewFeedCreator(t),
+				mbc:  storagemock.NewBadgeCreator(t),
+				mwc:  storagemock.NewWidgetCreator(t),
+				mdc:  storagemock.NewDataCreator(t),
+				moc:  storagemock.NewOGImageCreator(t),
 			}
 
 			test.mock(m)
@@ -231,9 +548,14 @@ func TestGenerate(t *testing.T) {
 				SettingsGetter:     m.mstg,
 				SystemGetter:       m.msg,
 				IRGetter:           m.mig,
+				PageGetter:         m.mpg,
 				UICreator:          m.muc,
 				PromMetricsCreator: m.mpc,
 				FeedCreator:        m.mfc,
+				BadgeCreator:       m.mbc,
+				WidgetCreator:      m.mwc,
+				DataCreator:        m.mdc,
+				OGImageCreator:     m.moc,
 				Logger:             log.Noop,
 			})
 			require.NoError(err)
@@ -250,9 +572,14 @@ func TestGenerate(t *testing.T) {
 			m.mstg.AssertExpectations(t)
 			m.msg.AssertExpectations(t)
 			m.mig.AssertExpectations(t)
+			m.mpg.AssertExpectations(t)
 			m.muc.AssertExpectations(t)
 			m.mpc.AssertExpectations(t)
 			m.mfc.AssertExpectations(t)
+			m.mbc.AssertExpectations(t)
+			m.mwc.AssertExpectations(t)
+			m.mdc.AssertExpectations(t)
+			m.moc.AssertExpectations(t)
 		})
 	}
 }
@@ -4,17 +4,48 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 // PrometheusMetricsPathName is the path where metrics will be served.
 const PrometheusMetricsPathName = "metrics"
 
+// SitemapPathName is the path where the sitemap will be created.
+const SitemapPathName = "sitemap.xml"
+
+// RobotsPathName is the path where the robots file will be created.
+const RobotsPathName = "robots.txt"
+
 // StaticFilesURLPrefix is the URL path where the static files will be served.
 const StaticFilesURLPrefix = "static"
 
 // IRHistoryAtomFeedPathName is the path where history Atom feed will be created.
 const IRHistoryAtomFeedPathName = "history-feed.atom"
 
+// IRHistoryRSSFeedPathName is the path where history RSS 2.0 feed will be created.
+const IRHistoryRSSFeedPathName = "history-feed.rss"
+
+// IRHistoryJSONFeedPathName is the path where history JSON feed will be created.
+const IRHistoryJSONFeedPathName = "history-feed.json"
+
+// WidgetJSPathName is the path where the embeddable status widget script will be created.
+const WidgetJSPathName = "widget.js"
+
+// WidgetStatusPathName is the path where the compact status used by the widget will be created.
+const WidgetStatusPathName = "status.json"
+
+// BadgeShieldsExt is the extension of the Shields.io endpoint JSON badges.
+const BadgeShieldsExt = "json"
+
+// BadgeSVGExt is the extension of the SVG badges.
+const BadgeSVGExt = "svg"
+
+// WidgetStatusURL standardizes the URL for serving the compact status used by the widget on an URL.
+func WidgetStatusURL(baseURL string) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return fmt.Sprintf("%s/%s", baseURL, WidgetStatusPathName)
+}
+
 // IRDetailURL standardizes the URL for serving an incident report detail on an URL.
 func IRDetailURL(baseURL, irID string) string {
 	baseURL = strings.TrimSuffix(baseURL, "/")
@@ -39,8 +70,245 @@ func IRHistoryFilePath(basePath string, page int) string {
 	return fmt.Sprintf("%s/history/%d.html", basePath, page)
 }
 
+// IRHistoryArchiveURL standardizes the URL for serving the incident report history archive of a month on an URL.
+func IRHistoryArchiveURL(baseURL string, year int, month time.Month) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return fmt.Sprintf("%s/history/%04d/%02d", baseURL, year, month)
+}
+
+// IRHistoryArchiveFilePath standardizes the file path for read/storing the incident report history archive of a month on an FS.
+func IRHistoryArchiveFilePath(basePath string, year int, month time.Month) string {
+	basePath = filepath.Clean(basePath)
+	return fmt.Sprintf("%s/history/%04d/%02d.html", basePath, year, month)
+}
+
+// SearchURL standardizes the URL for serving the incident search on an URL.
+func SearchURL(baseURL string) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return fmt.Sprintf("%s/search", baseURL)
+}
+
+// SearchFilePath standardizes the file path for read/storing the incident search on an FS.
+func SearchFilePath(basePath string) string {
+	basePath = filepath.Clean(basePath)
+	return fmt.Sprintf("%s/search.html", basePath)
+}
+
+// SearchIndexURL standardizes the URL for serving the incident search index manifest on an URL.
+func SearchIndexURL(baseURL string) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return fmt.Sprintf("%s/search/index.json", baseURL)
+}
+
+// SearchIndexFilePath standardizes the file path for read/storing the incident search index manifest on an FS.
+func SearchIndexFilePath(basePath string) string {
+	basePath = filepath.Clean(basePath)
+	return fmt.Sprintf("%s/search/index.json", basePath)
+}
+
+// SearchIndexShardURL standardizes the URL for serving an incident search index shard on an URL.
+func SearchIndexShardURL(baseURL string, shard int) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return fmt.Sprintf("%s/search/shard-%d.json", baseURL, shard)
+}
+
+// SearchIndexShardFilePath standardizes the file path for read/storing an incident search index shard on an FS.
+func SearchIndexShardFilePath(basePath string, shard int) string {
+	basePath = filepath.Clean(basePath)
+	return fmt.Sprintf("%s/search/shard-%d.json", basePath, shard)
+}
+
+// SystemDetailURL standardizes the URL for serving a system detail on an URL.
+func SystemDetailURL(baseURL, systemID string) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return fmt.Sprintf("%s/system/%s", baseURL, systemID)
+}
+
+// SystemDetailFilePath standardizes the file path for read/storing a system detail on an FS.
+func SystemDetailFilePath(basePath, systemID string) string {
+	basePath = filepath.Clean(basePath)
+	return fmt.Sprintf("%s/system/%s/index.html", basePath, systemID)
+}
+
+// SystemIRHistoryURL standardizes the URL for serving a system incident report history on an URL.
+// The first page (0) is the system detail itself.
+func SystemIRHistoryURL(baseURL, systemID string, page int) string {
+	if page == 0 {
+		return SystemDetailURL(baseURL, systemID)
+	}
+
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return fmt.Sprintf("%s/system/%s/history/%d", baseURL, systemID, page)
+}
+
+// SystemIRHistoryFilePath standardizes the file path for read/storing a system incident report history on an FS.
+// The first page (0) is the system detail itself.
+func SystemIRHistoryFilePath(basePath, systemID string, page int) string {
+	if page == 0 {
+		return SystemDetailFilePath(basePath, systemID)
+	}
+
+	basePath = filepath.Clean(basePath)
+	return fmt.Sprintf("%s/system/%s/history/%d.html", basePath, systemID, page)
+}
+
+// SystemIRHistoryFeedURL standardizes the URL for serving a system incident report history feed on an URL.
+// The feed path name is the one of the feed format (e.g: IRHistoryAtomFeedPathName).
+func SystemIRHistoryFeedURL(baseURL, systemID, feedPathName string) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return fmt.Sprintf("%s/system/%s/%s", baseURL, systemID, feedPathName)
+}
+
+// SystemIRHistoryFeedFilePath standardizes the file path for read/storing a system incident report history feed on an FS.
+// The feed path name is the one of the feed format (e.g: IRHistoryAtomFeedPathName).
+func SystemIRHistoryFeedFilePath(basePath, systemID, feedPathName string) string {
+	basePath = filepath.Clean(basePath)
+	return fmt.Sprintf("%s/system/%s/%s", basePath, systemID, feedPathName)
+}
+
+// PageURL standardizes the URL for serving a custom content page on an URL.
+func PageURL(baseURL, slug string) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return fmt.Sprintf("%s/page/%s", baseURL, slug)
+}
+
+// PageFilePath standardizes the file path for read/storing a custom content page on an FS.
+func PageFilePath(basePath, slug string) string {
+	basePath = filepath.Clean(basePath)
+	return fmt.Sprintf("%s/page/%s.html", basePath, slug)
+}
+
+// LocalizedSiteURL standardizes the root URL of the site of a language, the default language
+// site is served on the root and the rest under their language prefix (e.g: `/es`).
+func LocalizedSiteURL(baseURL, lang, defaultLang string) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	if lang == defaultLang {
+		return baseURL
+	}
+	return fmt.Sprintf("%s/%s", baseURL, lang)
+}
+
+// LocalizedSiteFilePath standardizes the root file path for read/storing the site of a language on an FS.
+func LocalizedSiteFilePath(basePath, lang, defaultLang string) string {
+	basePath = filepath.Clean(basePath)
+	if lang == defaultLang {
+		return basePath
+	}
+	return fmt.Sprintf("%s/%s", basePath, lang)
+}
+
 // IndexFilePath standardizes the file path for read/storing the site index on an FS.
 func IndexFilePath(basePath string) string {
 	basePath = filepath.Clean(basePath)
 	return fmt.Sprintf("%s/index.html", basePath)
 }
+
+// StatusBadgeURL standardizes the URL for serving the global status badge on an URL.
+func StatusBadgeURL(baseURL, ext string) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return fmt.Sprintf("%s/badges/status.%s", baseURL, ext)
+}
+
+// StatusBadgeFilePath standardizes the file path for read/storing the global status badge on an FS.
+func StatusBadgeFilePath(basePath, ext string) string {
+	basePath = filepath.Clean(basePath)
+	return fmt.Sprintf("%s/badges/status.%s", basePath, ext)
+}
+
+// SystemBadgeURL standardizes the URL for serving a system status badge on an URL.
+func SystemBadgeURL(baseURL, systemID, ext string) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return fmt.Sprintf("%s/badges/system/%s.%s", baseURL, systemID, ext)
+}
+
+// SystemBadgeFilePath standardizes the file path for read/storing a system status badge on an FS.
+func SystemBadgeFilePath(basePath, systemID, ext string) string {
+	basePath = filepath.Clean(basePath)
+	return fmt.Sprintf("%s/badges/system/%s.%s", basePath, systemID, ext)
+}
+
+// IndexOGImageURL standardizes the URL for serving the status page OpenGraph preview image on an URL.
+func IndexOGImageURL(baseURL string) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return fmt.Sprintf("%s/og/index.png", baseURL)
+}
+
+// IndexOGImageFilePath standardizes the file path for read/storing the status page OpenGraph preview image on an FS.
+func IndexOGImageFilePath(basePath string) string {
+	basePath = filepath.Clean(basePath)
+	return fmt.Sprintf("%s/og/index.png", basePath)
+}
+
+// IROGImageURL standardizes the URL for serving an incident report OpenGraph preview image on an URL.
+func IROGImageURL(baseURL, irID string) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return fmt.Sprintf("%s/og/ir/%s.png", baseURL, irID)
+}
+
+// IROGImageFilePath standardizes the file path for read/storing an incident report OpenGraph preview image on an FS.
+func IROGImageFilePath(basePath, irID string) string {
+	basePath = filepath.Clean(basePath)
+	return fmt.Sprintf("%s/og/ir/%s.png", basePath, irID)
+}
+
+// DataIndexURL standardizes the URL for serving the JSON data export index on an URL.
+func DataIndexURL(baseURL string) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return fmt.Sprintf("%s/api/v1/index.json", baseURL)
+}
+
+// DataIndexFilePath standardizes the file path for read/storing the JSON data export index on an FS.
+func DataIndexFilePath(basePath string) string {
+	basePath = filepath.Clean(basePath)
+	return fmt.Sprintf("%s/api/v1/index.json", basePath)
+}
+
+// DataIRURL standardizes the URL for serving an incident report JSON data on an URL.
+func DataIRURL(baseURL, irID string) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return fmt.Sprintf("%s/api/v1/ir/%s.json", baseURL, irID)
+}
+
+// DataIRFilePath standardizes the file path for read/storing an incident report JSON data on an FS.
+func DataIRFilePath(basePath, irID string) string {
+	basePath = filepath.Clean(basePath)
+	return fmt.Sprintf("%s/api/v1/ir/%s.json", basePath, irID)
+}
+
+// DataIRHistoryURL standardizes the URL for serving an incident report history JSON data page on an URL.
+func DataIRHistoryURL(baseURL string, page int) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return fmt.Sprintf("%s/api/v1/history/%d.json", baseURL, page)
+}
+
+// DataIRHistoryFilePath standardizes the file path for read/storing an incident report history JSON data page on an FS.
+func DataIRHistoryFilePath(basePath string, page int) string {
+	basePath = filepath.Clean(basePath)
+	return fmt.Sprintf("%s/api/v1/history/%d.json", basePath, page)
+}
+
+// DataSchemaURL standardizes the URL for serving a JSON data export JSON schema on an URL.
+func DataSchemaURL(baseURL, name string) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return fmt.Sprintf("%s/api/v1/schema/%s.json", baseURL, name)
+}
+
+// DataSchemaFilePath standardizes the file path for read/storing a JSON data export JSON schema on an FS.
+func DataSchemaFilePath(basePath, name string) string {
+	basePath = filepath.Clean(basePath)
+	return fmt.Sprintf("%s/api/v1/schema/%s.json", basePath, name)
+}
+
+// AssetURL standardizes the URL for serving a file referenced by the Markdown content (e.g: images) on
+// an URL, by its content hashed path.
+func AssetURL(baseURL, hashedPath string) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return fmt.Sprintf("%s/assets/%s", baseURL, strings.TrimPrefix(hashedPath, "/"))
+}
+
+// AssetFilePath standardizes the file path for read/storing a file referenced by the Markdown content on
+// an FS, by its content hashed path.
+func AssetFilePath(basePath, hashedPath string) string {
+	basePath = filepath.Clean(basePath)
+	return fmt.Sprintf("%s/assets/%s", basePath, strings.TrimPrefix(hashedPath, "/"))
+}
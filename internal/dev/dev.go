@@ -61,5 +61,5 @@ func NewAutogeneratedRepository() storagememory.Repository {
 	}
 
 	settings := model.StatusPageSettings{Name: "Development Site", URL: "http://127.0.0.1:8080"}
-	return storagememory.NewRepository(systems, settings, irs)
+	return storagememory.NewRepository(systems, settings, irs, nil)
 }
@@ -0,0 +1,24 @@
+package model
+
+import "fmt"
+
+// Audience is who a status page build is for.
+type Audience string
+
+const (
+	// AudiencePublic is the customer facing status page.
+	AudiencePublic Audience = "public"
+	// AudienceInternal is the private status page, it has everything the public one has and
+	// the internal only resources.
+	AudienceInternal Audience = "internal"
+)
+
+// Validate checks the audience is a known one.
+func (a Audience) Validate() error {
+	switch a {
+	case AudiencePublic, AudienceInternal:
+		return nil
+	}
+
+	return fmt.Errorf("unknown audience %q", a)
+}
@@ -1,7 +1,10 @@
 package model
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"path"
 	"sort"
 	"time"
 )
@@ -15,15 +18,67 @@ const (
 	IncidentImpactCritical IncidentImpact = "critical" // Red.
 )
 
+// Level returns the severity level of the impact, the higher the level, the worse the impact.
+func (i IncidentImpact) Level() int {
+	switch i {
+	case IncidentImpactMinor:
+		return 1
+	case IncidentImpactMajor:
+		return 2
+	case IncidentImpactCritical:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// IncidentVisibility is who can see an incident.
+type IncidentVisibility string
+
+const (
+	// IncidentVisibilityPublic incidents are shown on all the builds.
+	IncidentVisibilityPublic IncidentVisibility = "public"
+	// IncidentVisibilityDraft incidents are never published, only shown while previewing.
+	IncidentVisibilityDraft IncidentVisibility = "draft"
+	// IncidentVisibilityInternal incidents are only shown to the internal audience.
+	IncidentVisibilityInternal IncidentVisibility = "internal"
+)
+
 type IncidentReport struct {
-	ID        string
-	Name      string
-	SystemIDs []string
-	Start     time.Time
-	End       time.Time
-	Duration  time.Duration
-	Impact    IncidentImpact
-	Timeline  []IncidentReportEvent
+	ID         string
+	Name       string
+	SystemIDs  []string
+	Start      time.Time
+	End        time.Time
+	Duration   time.Duration
+	Impact     IncidentImpact
+	Timeline   []IncidentReportEvent
+	Visibility IncidentVisibility
+	// Postmortem is the root cause analysis of the incident, optional.
+	Postmortem *Postmortem
+	// LocalizedNames are the translated names by language, optional.
+	LocalizedNames map[string]string
+}
+
+// Postmortem is the root cause analysis document of an incident.
+type Postmortem struct {
+	Content string    // Markdown.
+	TS      time.Time // When it was published.
+	// Assets are the files referenced relatively by the content (e.g: images) by their reference.
+	Assets map[string]Asset
+}
+
+// Asset is a file referenced by a Markdown content (e.g: an image).
+type Asset struct {
+	Path string // Relative to the incidents directory.
+	Data []byte
+}
+
+// HashedPath returns the content addressed path of the asset (e.g: `3f1a9c0d2b4e5f60/latency.png`),
+// this way different files with the same name don't collide and they can be cached forever.
+func (a Asset) HashedPath() string {
+	sum := sha256.Sum256(a.Data)
+	return hex.EncodeToString(sum[:8]) + "/" + path.Base(a.Path)
 }
 
 type IncidentUpdateKind string
@@ -38,6 +93,16 @@ type IncidentReportEvent struct {
 	Description string
 	Kind        IncidentUpdateKind
 	TS          time.Time
+	// Audience that can see the event, empty means public.
+	Audience Audience
+	// PrivateNote are the extra details of the event only shown to the internal audience, optional.
+	PrivateNote string
+	// LocalizedDescriptions are the translated descriptions by language, optional.
+	LocalizedDescriptions map[string]string
+	// Assets are the files referenced relatively by the descriptions by their reference, optional.
+	Assets map[string]Asset
+	// PrivateNoteAssets are the files referenced relatively by the private note by their reference, optional.
+	PrivateNoteAssets map[string]Asset
 }
 
 func (i *IncidentReport) Validate() error {
@@ -53,9 +118,52 @@ func (i *IncidentReport) Validate() error {
 		return fmt.Errorf("name is required")
 	}
 
+	for _, ev := range i.Timeline {
+		if ev.Audience != "" {
+			err := ev.Audience.Validate()
+			if err != nil {
+				return fmt.Errorf("invalid event: %w", err)
+			}
+		}
+	}
+
+	switch i.Visibility {
+	case "":
+		i.Visibility = IncidentVisibilityPublic
+	case IncidentVisibilityPublic, IncidentVisibilityDraft, IncidentVisibilityInternal:
+	default:
+		return fmt.Errorf("unknown visibility %q", i.Visibility)
+	}
+
 	// Sort desc in the event TS.
 	sort.SliceStable(i.Timeline, func(ii, jj int) bool { return i.Timeline[ii].TS.After(i.Timeline[jj].TS) })
 
+	i.setTimes()
+
+	if i.Postmortem != nil {
+		if i.Postmortem.Content == "" {
+			return fmt.Errorf("postmortem content is required")
+		}
+
+		// By default published when the incident ended (or on the latest update if still open).
+		if i.Postmortem.TS.IsZero() {
+			i.Postmortem.TS = i.End
+			if i.Postmortem.TS.IsZero() {
+				i.Postmortem.TS = i.Timeline[0].TS
+			}
+		}
+	}
+
+	return nil
+}
+
+// setTimes sets the start, end and duration of the incident based on its timeline, the
+// timeline must be sorted desc.
+func (i *IncidentReport) setTimes() {
+	if len(i.Timeline) == 0 {
+		return
+	}
+
 	// Set the end of the incident to the resolved event (if any of the events has the resolved kind).
 	for _, ev := range i.Timeline {
 		if ev.Kind == IncidentUpdateKindResolved {
@@ -71,6 +179,77 @@ func (i *IncidentReport) Validate() error {
 	if !i.End.IsZero() {
 		i.Duration = i.End.Sub(i.Start)
 	}
+}
 
-	return nil
+// VisibleTo returns true if the incident can be shown to the audience, drafts are only
+// visible when previewing.
+func (i IncidentReport) VisibleTo(audience Audience, preview bool) bool {
+	switch i.Visibility {
+	case IncidentVisibilityDraft:
+		return preview
+	case IncidentVisibilityInternal:
+		return audience == AudienceInternal
+	default:
+		return true
+	}
+}
+
+// ForAudience returns a copy of the incident with only the information the audience can
+// see, the not visible systems, the events of other audiences and the private notes are removed.
+func (i IncidentReport) ForAudience(audience Audience, visibleSystemIDs map[string]bool) IncidentReport {
+	if audience == AudienceInternal {
+		return i
+	}
+
+	if len(i.SystemIDs) > 0 {
+		systemIDs := []string{}
+		for _, id := range i.SystemIDs {
+			if visibleSystemIDs[id] {
+				systemIDs = append(systemIDs, id)
+			}
+		}
+		i.SystemIDs = systemIDs
+	}
+
+	if len(i.Timeline) > 0 {
+		timeline := []IncidentReportEvent{}
+		for _, ev := range i.Timeline {
+			if ev.Audience == AudienceInternal {
+				continue
+			}
+			ev.PrivateNote = ""
+			ev.PrivateNoteAssets = nil
+			timeline = append(timeline, ev)
+		}
+
+		// The audience only knows about the events it can see (e.g: an internal resolution
+		// should not close the incident).
+		hiddenEvents := len(timeline) != len(i.Timeline)
+		i.Timeline = timeline
+		if hiddenEvents {
+			i.Start, i.End, i.Duration = time.Time{}, time.Time{}, 0
+			i.setTimes()
+		}
+	}
+
+	return i
+}
+
+// Localized returns a copy of the incident with the texts in the required language, the
+// texts without translation will keep the default language ones.
+func (i IncidentReport) Localized(lang string) IncidentReport {
+	if name, ok := i.LocalizedNames[lang]; ok && name != "" {
+		i.Name = name
+	}
+
+	timeline := make([]IncidentReportEvent, 0, len(i.Timeline))
+	for _, ev := range i.Timeline {
+		if desc, ok := ev.LocalizedDescriptions[lang]; ok && desc != "" {
+			ev.Description = desc
+		}
+		timeline = append(timeline, ev)
+	}
+	i.Timeline = timeline
+
+	return i
 }
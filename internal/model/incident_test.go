@@ -15,13 +15,14 @@ var (
 
 func getBaseIncidentReport() model.IncidentReport {
 	return model.IncidentReport{
-		ID:        "test-id",
-		Name:      "Test 1",
-		Start:     t0,
-		End:       t1,
-		Duration:  17 * time.Minute,
-		SystemIDs: []string{"system1", "system2"},
-		Impact:    model.IncidentImpactCritical,
+		ID:         "test-id",
+		Name:       "Test 1",
+		Start:      t0,
+		End:        t1,
+		Duration:   17 * time.Minute,
+		SystemIDs:  []string{"system1", "system2"},
+		Impact:     model.IncidentImpactCritical,
+		Visibility: model.IncidentVisibilityPublic,
 		Timeline: []model.IncidentReportEvent{
 			{Description: "desc1", Kind: model.IncidentUpdateKindResolved, TS: t1},
 			{Description: "desc2", Kind: model.IncidentUpdateKindInvestigating, TS: t0},
@@ -84,6 +85,72 @@ func TestIncidentReportValidate(t *testing.T) {
 			},
 			expIR: getBaseIncidentReport,
 		},
+
+		"Not having visibility, should be public by default.": {
+			ir: func() model.IncidentReport {
+				ir := getBaseIncidentReport()
+				ir.Visibility = ""
+				return ir
+			},
+			expIR: getBaseIncidentReport,
+		},
+
+		"Having an event with an unknown audience should fail.": {
+			ir: func() model.IncidentReport {
+				ir := getBaseIncidentReport()
+				ir.Timeline[0].Audience = "everyone"
+				return ir
+			},
+			expErr: true,
+		},
+
+		"Having an unknown visibility should fail.": {
+			ir: func() model.IncidentReport {
+				ir := getBaseIncidentReport()
+				ir.Visibility = "secret"
+				return ir
+			},
+			expErr: true,
+		},
+
+		"Having a postmortem without content should fail.": {
+			ir: func() model.IncidentReport {
+				ir := getBaseIncidentReport()
+				ir.Postmortem = &model.Postmortem{}
+				return ir
+			},
+			expErr: true,
+		},
+
+		"Having a postmortem without TS, should be published when the incident ended.": {
+			ir: func() model.IncidentReport {
+				ir := getBaseIncidentReport()
+				ir.Postmortem = &model.Postmortem{Content: "## Root cause"}
+				return ir
+			},
+			expIR: func() model.IncidentReport {
+				ir := getBaseIncidentReport()
+				ir.Postmortem = &model.Postmortem{Content: "## Root cause", TS: t1}
+				return ir
+			},
+		},
+
+		"Having a postmortem on an open incident without TS, should be published on the latest update.": {
+			ir: func() model.IncidentReport {
+				ir := getBaseIncidentReport()
+				ir.Timeline[0].Kind = model.IncidentUpdateKindUpdate
+				ir.End = time.Time{}
+				ir.Postmortem = &model.Postmortem{Content: "## Root cause"}
+				return ir
+			},
+			expIR: func() model.IncidentReport {
+				ir := getBaseIncidentReport()
+				ir.Timeline[0].Kind = model.IncidentUpdateKindUpdate
+				ir.End = time.Time{}
+				ir.Postmortem = &model.Postmortem{Content: "## Root cause", TS: t1}
+				return ir
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -99,3 +166,221 @@ func TestIncidentReportValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestIncidentImpactLevel(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0, model.IncidentImpactNone.Level())
+	assert.Less(model.IncidentImpactNone.Level(), model.IncidentImpactMinor.Level())
+	assert.Less(model.IncidentImpactMinor.Level(), model.IncidentImpactMajor.Level())
+	assert.Less(model.IncidentImpactMajor.Level(), model.IncidentImpactCritical.Level())
+}
+
+func TestIncidentReportVisibleTo(t *testing.T) {
+	tests := map[string]struct {
+		visibility model.IncidentVisibility
+		audience   model.Audience
+		preview    bool
+		expVisible bool
+	}{
+		"Public incidents should be visible to the public audience.": {
+			visibility: model.IncidentVisibilityPublic,
+			audience:   model.AudiencePublic,
+			expVisible: true,
+		},
+
+		"Public incidents should be visible to the internal audience.": {
+			visibility: model.IncidentVisibilityPublic,
+			audience:   model.AudienceInternal,
+			expVisible: true,
+		},
+
+		"Internal incidents should not be visible to the public audience.": {
+			visibility: model.IncidentVisibilityInternal,
+			audience:   model.AudiencePublic,
+			preview:    true,
+			expVisible: false,
+		},
+
+		"Internal incidents should be visible to the internal audience.": {
+			visibility: model.IncidentVisibilityInternal,
+			audience:   model.AudienceInternal,
+			expVisible: true,
+		},
+
+		"Draft incidents should not be visible to any audience.": {
+			visibility: model.IncidentVisibilityDraft,
+			audience:   model.AudienceInternal,
+			expVisible: false,
+		},
+
+		"Draft incidents should be visible while previewing.": {
+			visibility: model.IncidentVisibilityDraft,
+			audience:   model.AudiencePublic,
+			preview:    true,
+			expVisible: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ir := getBaseIncidentReport()
+			ir.Visibility = test.visibility
+
+			assert.Equal(t, test.expVisible, ir.VisibleTo(test.audience, test.preview))
+		})
+	}
+}
+
+func TestIncidentReportForAudience(t *testing.T) {
+	getAudienceIR := func() model.IncidentReport {
+		ir := getBaseIncidentReport()
+		ir.Timeline = []model.IncidentReportEvent{
+			{Description: "desc1", Kind: model.IncidentUpdateKindResolved, TS: t1},
+			{Description: "desc2", Kind: model.IncidentUpdateKindUpdate, TS: t0.Add(time.Minute), Audience: model.AudienceInternal},
+			{Description: "desc3", Kind: model.IncidentUpdateKindInvestigating, TS: t0, PrivateNote: "note3 ![db](./db.png)",
+				PrivateNoteAssets: map[string]model.Asset{"./db.png": {Path: "db.png", Data: []byte("png")}}},
+		}
+		return ir
+	}
+
+	tests := map[string]struct {
+		ir               func() model.IncidentReport
+		audience         model.Audience
+		visibleSystemIDs map[string]bool
+		expIR            func() model.IncidentReport
+	}{
+		"The internal audience should see everything.": {
+			ir:       getAudienceIR,
+			audience: model.AudienceInternal,
+			expIR:    getAudienceIR,
+		},
+
+		"The public audience should not see the internal systems, events and private notes.": {
+			ir:               getAudienceIR,
+			audience:         model.AudiencePublic,
+			visibleSystemIDs: map[string]bool{"system2": true},
+			expIR: func() model.IncidentReport {
+				ir := getBaseIncidentReport()
+				ir.SystemIDs = []string{"system2"}
+				ir.Timeline = []model.IncidentReportEvent{
+					{Description: "desc1", Kind: model.IncidentUpdateKindResolved, TS: t1},
+					{Description: "desc3", Kind: model.IncidentUpdateKindInvestigating, TS: t0},
+				}
+				return ir
+			},
+		},
+
+		"The public audience should not see the private notes without internal events.": {
+			ir: func() model.IncidentReport {
+				ir := getBaseIncidentReport()
+				ir.Timeline = []model.IncidentReportEvent{
+					{Description: "desc1", Kind: model.IncidentUpdateKindResolved, TS: t1, PrivateNote: "SECRET"},
+					{Description: "desc2", Kind: model.IncidentUpdateKindInvestigating, TS: t0, PrivateNote: "note2 ![db](./db.png)",
+						PrivateNoteAssets: map[string]model.Asset{"./db.png": {Path: "db.png", Data: []byte("png")}}},
+				}
+				return ir
+			},
+			audience:         model.AudiencePublic,
+			visibleSystemIDs: map[string]bool{"system1": true, "system2": true},
+			expIR: func() model.IncidentReport {
+				ir := getBaseIncidentReport()
+				ir.Timeline = []model.IncidentReportEvent{
+					{Description: "desc1", Kind: model.IncidentUpdateKindResolved, TS: t1},
+					{Description: "desc2", Kind: model.IncidentUpdateKindInvestigating, TS: t0},
+				}
+				return ir
+			},
+		},
+
+		"The public audience times should be based on the events it can see.": {
+			ir: func() model.IncidentReport {
+				ir := getBaseIncidentReport()
+				ir.Timeline = []model.IncidentReportEvent{
+					{Description: "desc1", Kind: model.IncidentUpdateKindResolved, TS: t1, Audience: model.AudienceInternal},
+					{Description: "desc2", Kind: model.IncidentUpdateKindUpdate, TS: t0.Add(time.Minute)},
+					{Description: "desc3", Kind: model.IncidentUpdateKindInvestigating, TS: t0, Audience: model.AudienceInternal},
+				}
+				return ir
+			},
+			audience:         model.AudiencePublic,
+			visibleSystemIDs: map[string]bool{"system1": true, "system2": true},
+			expIR: func() model.IncidentReport {
+				ir := getBaseIncidentReport()
+				ir.Start = t0.Add(time.Minute)
+				ir.End = time.Time{}
+				ir.Duration = 0
+				ir.Timeline = []model.IncidentReportEvent{
+					{Description: "desc2", Kind: model.IncidentUpdateKindUpdate, TS: t0.Add(time.Minute)},
+				}
+				return ir
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			ir := test.ir()
+			gotIR := ir.ForAudience(test.audience, test.visibleSystemIDs)
+
+			assert.Equal(test.expIR(), gotIR)
+			assert.Equal(test.ir(), ir, "original incident should not be modified")
+		})
+	}
+}
+
+func TestAssetHashedPath(t *testing.T) {
+	assert := assert.New(t)
+
+	a1 := model.Asset{Path: "2024/img/latency.png", Data: []byte("png")}
+	a2 := model.Asset{Path: "2025/latency.png", Data: []byte("png")}
+	a3 := model.Asset{Path: "2024/img/latency.png", Data: []byte("other png")}
+
+	assert.Equal("8f8cbb7dcf46e0bc/latency.png", a1.HashedPath())
+	assert.Equal(a1.HashedPath(), a2.HashedPath(), "same content should have the same path")
+	assert.NotEqual(a1.HashedPath(), a3.HashedPath(), "different content should have a different path")
+}
+
+func TestIncidentReportLocalized(t *testing.T) {
+	getLocalizedIR := func() model.IncidentReport {
+		ir := getBaseIncidentReport()
+		ir.LocalizedNames = map[string]string{"es": "Prueba 1"}
+		ir.Timeline[0].LocalizedDescriptions = map[string]string{"es": "desc1 es"}
+		return ir
+	}
+
+	tests := map[string]struct {
+		ir    func() model.IncidentReport
+		lang  string
+		expIR func() model.IncidentReport
+	}{
+		"An incident with translations should use the localized texts.": {
+			ir:   getLocalizedIR,
+			lang: "es",
+			expIR: func() model.IncidentReport {
+				ir := getLocalizedIR()
+				ir.Name = "Prueba 1"
+				ir.Timeline[0].Description = "desc1 es"
+				return ir
+			},
+		},
+
+		"An incident without translations should fall back to the default texts.": {
+			ir:    getLocalizedIR,
+			lang:  "fr",
+			expIR: getLocalizedIR,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			ir := test.ir()
+			gotIR := ir.Localized(test.lang)
+
+			assert.Equal(test.expIR(), gotIR)
+			assert.Equal(test.ir(), ir, "original incident should not be modified")
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Page is a custom content page of the status page (e.g: About, support hours...).
+type Page struct {
+	Slug     string
+	Title    string
+	NavOrder int
+	Content  string // Markdown.
+}
+
+var pageSlugRe = regexp.MustCompile(`^[a-z0-9]+(?:[-_][a-z0-9]+)*$`)
+
+func (p *Page) Validate() error {
+	if p.Slug == "" {
+		return fmt.Errorf("slug is required")
+	}
+
+	if !pageSlugRe.MatchString(p.Slug) {
+		return fmt.Errorf("slug %q is invalid, only lowercase alphanumeric, '-' and '_' are allowed", p.Slug)
+	}
+
+	if p.Title == "" {
+		return fmt.Errorf("title is required")
+	}
+
+	return nil
+}
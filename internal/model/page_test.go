@@ -0,0 +1,70 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/slok/stactus/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func getBasePage() model.Page {
+	return model.Page{
+		Slug:     "test-page",
+		Title:    "Test 1",
+		NavOrder: 2,
+		Content:  "something **test**",
+	}
+}
+
+func TestPageValidate(t *testing.T) {
+	tests := map[string]struct {
+		page    func() model.Page
+		expPage func() model.Page
+		expErr  bool
+	}{
+		"A correct page should validate correctly.": {
+			page:    getBasePage,
+			expPage: getBasePage,
+		},
+
+		"A missing slug should fail.": {
+			page: func() model.Page {
+				p := getBasePage()
+				p.Slug = ""
+				return p
+			},
+			expErr: true,
+		},
+
+		"A slug with invalid URL chars should fail.": {
+			page: func() model.Page {
+				p := getBasePage()
+				p.Slug = "test/page"
+				return p
+			},
+			expErr: true,
+		},
+
+		"A missing title should fail.": {
+			page: func() model.Page {
+				p := getBasePage()
+				p.Title = ""
+				return p
+			},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			p := test.page()
+			err := p.Validate()
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expPage(), p)
+			}
+		})
+	}
+}
@@ -2,13 +2,58 @@ package model
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
+
+	utilhtml "github.com/slok/stactus/internal/util/html"
 )
 
 type StatusPageSettings struct {
-	Name  string // E.g: GitHub.
-	URL   string // E.g: https://statusgithub.com/.
-	Theme Theme
+	Name    string // E.g: GitHub.
+	URL     string // E.g: https://statusgithub.com/.
+	Theme   Theme
+	Feed    FeedSettings
+	Metrics MetricsSettings
+	SEO     SEOSettings
+	// Markdown rendering of the incidents and pages contents.
+	Markdown MarkdownSettings
+	// Languages of the status page, a site will be generated per language, the first
+	// one is the default language. If empty, only English will be used.
+	Languages []string
+	// Audiences that have their own status page, optional.
+	Audiences []AudienceSettings
+}
+
+// AudienceSettings are the settings of the status page of an audience.
+type AudienceSettings struct {
+	Audience Audience
+	URL      string // If empty, the status page URL will be used.
+}
+
+// AudienceURL returns the URL of the audience status page.
+func (s StatusPageSettings) AudienceURL(audience Audience) string {
+	for _, a := range s.Audiences {
+		if a.Audience == audience && a.URL != "" {
+			return a.URL
+		}
+	}
+
+	return s.URL
+}
+
+// DefaultLanguage is the language used when the status page doesn't set any.
+const DefaultLanguage = "en"
+
+var languageRegexp = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})?$`)
+
+// DefaultLanguage returns the main language of the status page.
+func (s StatusPageSettings) DefaultLanguage() string {
+	if len(s.Languages) == 0 {
+		return DefaultLanguage
+	}
+
+	return s.Languages[0]
 }
 
 func (s *StatusPageSettings) Validate() error {
@@ -23,15 +68,219 @@ func (s *StatusPageSettings) Validate() error {
 		return fmt.Errorf("at least one theme must be selected")
 	}
 
+	switch s.Theme.Simple.ColorScheme {
+	case "", ColorSchemeAuto, ColorSchemeLight, ColorSchemeDark:
+	default:
+		return fmt.Errorf("unknown color scheme %q", s.Theme.Simple.ColorScheme)
+	}
+
+	if s.Feed.HistoryItems < 0 {
+		return fmt.Errorf("feed history items can't be negative")
+	}
+
+	switch s.Feed.Mode {
+	case "", FeedModeIncident, FeedModeUpdate:
+	default:
+		return fmt.Errorf("unknown feed mode %q", s.Feed.Mode)
+	}
+
+	err := s.Metrics.validate()
+	if err != nil {
+		return fmt.Errorf("invalid metrics settings: %w", err)
+	}
+
+	err = s.Markdown.validate()
+	if err != nil {
+		return fmt.Errorf("invalid markdown settings: %w", err)
+	}
+
+	langs := map[string]bool{}
+	for _, l := range s.Languages {
+		if !languageRegexp.MatchString(l) {
+			return fmt.Errorf("invalid language %q, must be a language code (e.g: es, pt-BR)", l)
+		}
+		if langs[l] {
+			return fmt.Errorf("language %q is repeated", l)
+		}
+		langs[l] = true
+	}
+
+	audiences := map[Audience]bool{}
+	for i, a := range s.Audiences {
+		err := a.Audience.Validate()
+		if err != nil {
+			return fmt.Errorf("invalid audience: %w", err)
+		}
+		if audiences[a.Audience] {
+			return fmt.Errorf("audience %q is repeated", a.Audience)
+		}
+		audiences[a.Audience] = true
+
+		a.URL = strings.TrimSuffix(strings.TrimSpace(a.URL), "/")
+		s.Audiences[i] = a
+	}
+
 	return nil
 }
 
 type Theme struct {
 	// Can override the templates of any theme.
 	OverrideTPLPath string
+	// Can override or add translations of the theme messages.
+	TranslationsPath string
 
 	// Themes settings, the one that is not null, it's the one being used.
 	Simple *ThemeSimple
 }
 
-type ThemeSimple struct{}
+type ThemeSimple struct {
+	// ColorScheme of the pages, if empty the auto color scheme will be used.
+	ColorScheme ColorScheme
+	// HighContrast uses a high contrast variant of the color scheme.
+	HighContrast bool
+}
+
+// ColorScheme is the light or dark mode of a theme.
+type ColorScheme string
+
+const (
+	// ColorSchemeAuto will follow the user preference (`prefers-color-scheme`).
+	ColorSchemeAuto  ColorScheme = "auto"
+	ColorSchemeLight ColorScheme = "light"
+	ColorSchemeDark  ColorScheme = "dark"
+)
+
+// FeedMode is how the incident history is mapped to the feed entries.
+type FeedMode string
+
+const (
+	// FeedModeIncident will have an entry per incident, updated with the latest incident event.
+	FeedModeIncident FeedMode = "incident"
+	// FeedModeUpdate will have an entry per incident event (update stream).
+	FeedModeUpdate FeedMode = "update"
+)
+
+type FeedSettings struct {
+	// HistoryItems is the max number of entries per feed, if 0 the default will be used.
+	HistoryItems int
+	// Mode of the feed entries, if empty the incident mode will be used.
+	Mode FeedMode
+	// By default all feed formats are enabled.
+	DisableAtom bool
+	DisableRSS  bool
+	DisableJSON bool
+}
+
+type SEOSettings struct {
+	// NoIndex asks the search engines to not index the status page (e.g: internal status pages).
+	NoIndex bool
+	// Description of the status page, if empty a description based on the status will be used.
+	Description string
+	// Robots is the content of the `robots.txt` file, if empty the default will be used.
+	Robots string
+}
+
+// MarkdownSettings are the settings of how the Markdown contents are rendered, the
+// resulting HTML is always sanitized.
+type MarkdownSettings struct {
+	// By default all the GFM extensions are enabled.
+	DisableTables        bool
+	DisableStrikethrough bool
+	DisableAutolinks     bool
+	DisableTaskLists     bool
+	DisableFootnotes     bool
+	// RawHTML renders the raw HTML of the contents (sanitized), by default it's omitted.
+	RawHTML bool
+	// HeadingAnchors adds a link to each heading so it can be shared.
+	HeadingAnchors bool
+	// CodeHighlightStyle is the style of the highlighted code blocks (e.g: `github`), if empty
+	// the code blocks will not be highlighted.
+	CodeHighlightStyle string
+	// ExternalLinkRel is the `rel` of the links to other sites, if empty the default will be used.
+	ExternalLinkRel string
+	// ExternalLinkTarget is the `target` of the links to other sites (e.g: `_blank`), optional.
+	ExternalLinkTarget string
+}
+
+func (m MarkdownSettings) validate() error {
+	err := utilhtml.ValidateCodeHighlightStyle(m.CodeHighlightStyle)
+	if err != nil {
+		return err
+	}
+
+	err = utilhtml.ValidateExternalLinkRel(m.ExternalLinkRel)
+	if err != nil {
+		return err
+	}
+
+	err = utilhtml.ValidateExternalLinkTarget(m.ExternalLinkTarget)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MetricsFormat is the exposition format of the metrics.
+type MetricsFormat string
+
+const (
+	MetricsFormatPrometheus  MetricsFormat = "prometheus"
+	MetricsFormatOpenMetrics MetricsFormat = "openmetrics"
+)
+
+// MetricsReservedLabel is the constant label that stactus sets on all the metrics.
+const MetricsReservedLabel = "status_page"
+
+// Metrics variable labels, set by stactus per metric series.
+const (
+	MetricsLabelID       = "id"
+	MetricsLabelName     = "name"
+	MetricsLabelImpact   = "impact"
+	MetricsLabelStatusOK = "status_ok"
+	MetricsLabelVersion  = "version"
+)
+
+// MetricsVariableLabels are the labels of the metrics series, they can't be used as extra labels.
+var MetricsVariableLabels = []string{
+	MetricsLabelID,
+	MetricsLabelName,
+	MetricsLabelImpact,
+	MetricsLabelStatusOK,
+	MetricsLabelVersion,
+}
+
+var metricNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+type MetricsSettings struct {
+	// Format of the metrics, if empty the Prometheus text format will be used.
+	Format MetricsFormat
+	// Namespace of the metrics, if empty the default will be used.
+	Namespace string
+	// Labels are extra constant labels for all the metrics.
+	Labels map[string]string
+}
+
+func (m MetricsSettings) validate() error {
+	switch m.Format {
+	case "", MetricsFormatPrometheus, MetricsFormatOpenMetrics:
+	default:
+		return fmt.Errorf("unknown metrics format %q", m.Format)
+	}
+
+	if m.Namespace != "" && !metricNameRegexp.MatchString(m.Namespace) {
+		return fmt.Errorf("invalid metrics namespace %q", m.Namespace)
+	}
+
+	for k := range m.Labels {
+		if !metricNameRegexp.MatchString(k) || strings.HasPrefix(k, "__") {
+			return fmt.Errorf("invalid metrics label name %q", k)
+		}
+
+		if k == MetricsReservedLabel || slices.Contains(MetricsVariableLabels, k) {
+			return fmt.Errorf("metrics label %q is reserved", k)
+		}
+	}
+
+	return nil
+}
@@ -37,6 +37,199 @@ func TestStatusPageSettingsValidate(t *testing.T) {
 			expErr: true,
 		},
 
+		"A negative feed history items should fail.": {
+			system: func() model.StatusPageSettings {
+				s := getBaseSettings()
+				s.Feed.HistoryItems = -1
+				return s
+			},
+			expErr: true,
+		},
+
+		"An unknown feed mode should fail.": {
+			system: func() model.StatusPageSettings {
+				s := getBaseSettings()
+				s.Feed.Mode = "something"
+				return s
+			},
+			expErr: true,
+		},
+
+		"Valid metrics settings should validate correctly.": {
+			system: func() model.StatusPageSettings {
+				s := getBaseSettings()
+				s.Metrics = model.MetricsSettings{Format: model.MetricsFormatOpenMetrics, Namespace: "my_status", Labels: map[string]string{"env": "prod"}}
+				return s
+			},
+			expStatusPageSettings: func() model.StatusPageSettings {
+				s := getBaseSettings()
+				s.Metrics = model.MetricsSettings{Format: model.MetricsFormatOpenMetrics, Namespace: "my_status", Labels: map[string]string{"env": "prod"}}
+				return s
+			},
+		},
+
+		"An unknown metrics format should fail.": {
+			system: func() model.StatusPageSettings {
+				s := getBaseSettings()
+				s.Metrics.Format = "something"
+				return s
+			},
+			expErr: true,
+		},
+
+		"An invalid metrics namespace should fail.": {
+			system: func() model.StatusPageSettings {
+				s := getBaseSettings()
+				s.Metrics.Namespace = "my-status"
+				return s
+			},
+			expErr: true,
+		},
+
+		"An invalid metrics label name should fail.": {
+			system: func() model.StatusPageSettings {
+				s := getBaseSettings()
+				s.Metrics.Labels = map[string]string{"__env": "prod"}
+				return s
+			},
+			expErr: true,
+		},
+
+		"A reserved metrics label name should fail.": {
+			system: func() model.StatusPageSettings {
+				s := getBaseSettings()
+				s.Metrics.Labels = map[string]string{"status_page": "prod"}
+				return s
+			},
+			expErr: true,
+		},
+
+		"A metrics label name used by the metrics series should fail.": {
+			system: func() model.StatusPageSettings {
+				s := getBaseSettings()
+				s.Metrics.Labels = map[string]string{"status_ok": "true"}
+				return s
+			},
+			expErr: true,
+		},
+
+		"Valid markdown settings should validate correctly.": {
+			system: func() model.StatusPageSettings {
+				s := getBaseSettings()
+				s.Markdown = model.MarkdownSettings{DisableFootnotes: true, CodeHighlightStyle: "github", ExternalLinkRel: "noopener nofollow", ExternalLinkTarget: "_blank"}
+				return s
+			},
+			expStatusPageSettings: func() model.StatusPageSettings {
+				s := getBaseSettings()
+				s.Markdown = model.MarkdownSettings{DisableFootnotes: true, CodeHighlightStyle: "github", ExternalLinkRel: "noopener nofollow", ExternalLinkTarget: "_blank"}
+				return s
+			},
+		},
+
+		"An invalid markdown external link rel should fail.": {
+			system: func() model.StatusPageSettings {
+				s := getBaseSettings()
+				s.Markdown.ExternalLinkRel = `noopener" onclick="alert(1)`
+				return s
+			},
+			expErr: true,
+		},
+
+		"An unknown markdown external link target should fail.": {
+			system: func() model.StatusPageSettings {
+				s := getBaseSettings()
+				s.Markdown.ExternalLinkTarget = "blank"
+				return s
+			},
+			expErr: true,
+		},
+
+		"An unknown markdown code highlight style should fail.": {
+			system: func() model.StatusPageSettings {
+				s := getBaseSettings()
+				s.Markdown.CodeHighlightStyle = "not-a-style"
+				return s
+			},
+			expErr: true,
+		},
+
+		"Valid languages should validate correctly.": {
+			system: func() model.StatusPageSettings {
+				s := getBaseSettings()
+				s.Languages = []string{"en", "es", "pt-BR"}
+				return s
+			},
+			expStatusPageSettings: func() model.StatusPageSettings {
+				s := getBaseSettings()
+				s.Languages = []string{"en", "es", "pt-BR"}
+				return s
+			},
+		},
+
+		"An invalid language should fail.": {
+			system: func() model.StatusPageSettings {
+				s := getBaseSettings()
+				s.Languages = []string{"en", "Spanish"}
+				return s
+			},
+			expErr: true,
+		},
+
+		"A repeated language should fail.": {
+			system: func() model.StatusPageSettings {
+				s := getBaseSettings()
+				s.Languages = []string{"en", "es", "en"}
+				return s
+			},
+			expErr: true,
+		},
+
+		"An unknown color scheme should fail.": {
+			system: func() model.StatusPageSettings {
+				s := getBaseSettings()
+				s.Theme.Simple.ColorScheme = "sepia"
+				return s
+			},
+			expErr: true,
+		},
+
+		"Audiences should be validated and their URLs sanitized.": {
+			system: func() model.StatusPageSettings {
+				s := getBaseSettings()
+				s.Audiences = []model.AudienceSettings{
+					{Audience: model.AudiencePublic},
+					{Audience: model.AudienceInternal, URL: " https://internal.something.io/ "},
+				}
+				return s
+			},
+			expStatusPageSettings: func() model.StatusPageSettings {
+				s := getBaseSettings()
+				s.Audiences = []model.AudienceSettings{
+					{Audience: model.AudiencePublic},
+					{Audience: model.AudienceInternal, URL: "https://internal.something.io"},
+				}
+				return s
+			},
+		},
+
+		"An unknown audience should fail.": {
+			system: func() model.StatusPageSettings {
+				s := getBaseSettings()
+				s.Audiences = []model.AudienceSettings{{Audience: "everyone"}}
+				return s
+			},
+			expErr: true,
+		},
+
+		"A repeated audience should fail.": {
+			system: func() model.StatusPageSettings {
+				s := getBaseSettings()
+				s.Audiences = []model.AudienceSettings{{Audience: model.AudienceInternal}, {Audience: model.AudienceInternal}}
+				return s
+			},
+			expErr: true,
+		},
+
 		"A missing theme should fail.": {
 			system: func() model.StatusPageSettings {
 				s := getBaseSettings()
@@ -60,3 +253,16 @@ func TestStatusPageSettingsValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestStatusPageSettingsAudienceURL(t *testing.T) {
+	assert := assert.New(t)
+
+	s := getBaseSettings()
+	s.Audiences = []model.AudienceSettings{
+		{Audience: model.AudiencePublic},
+		{Audience: model.AudienceInternal, URL: "https://internal.something.io"},
+	}
+
+	assert.Equal("https://something.io", s.AudienceURL(model.AudiencePublic))
+	assert.Equal("https://internal.something.io", s.AudienceURL(model.AudienceInternal))
+}
@@ -6,6 +6,8 @@ type System struct {
 	ID          string
 	Name        string
 	Description string
+	// Audience that can see the system, empty means public.
+	Audience Audience
 }
 
 func (s *System) Validate() error {
@@ -17,5 +19,17 @@ func (s *System) Validate() error {
 		s.Name = s.ID
 	}
 
+	if s.Audience != "" {
+		err := s.Audience.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+// VisibleTo returns true if the system can be shown to the audience.
+func (s System) VisibleTo(audience Audience) bool {
+	return s.Audience != AudienceInternal || audience == AudienceInternal
+}
@@ -47,6 +47,28 @@ func TestSystemValidate(t *testing.T) {
 				return s
 			},
 		},
+
+		"An internal system should validate correctly.": {
+			system: func() model.System {
+				s := getBaseSystem()
+				s.Audience = model.AudienceInternal
+				return s
+			},
+			expSystem: func() model.System {
+				s := getBaseSystem()
+				s.Audience = model.AudienceInternal
+				return s
+			},
+		},
+
+		"An unknown audience should fail.": {
+			system: func() model.System {
+				s := getBaseSystem()
+				s.Audience = "everyone"
+				return s
+			},
+			expErr: true,
+		},
 	}
 
 	for name, test := range tests {
@@ -62,3 +84,16 @@ func TestSystemValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestSystemVisibleTo(t *testing.T) {
+	assert := assert.New(t)
+
+	public := getBaseSystem()
+	internal := getBaseSystem()
+	internal.Audience = model.AudienceInternal
+
+	assert.True(public.VisibleTo(model.AudiencePublic))
+	assert.True(public.VisibleTo(model.AudienceInternal))
+	assert.False(internal.VisibleTo(model.AudiencePublic))
+	assert.True(internal.VisibleTo(model.AudienceInternal))
+}
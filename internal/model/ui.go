@@ -6,6 +6,23 @@ type SystemDetails struct {
 	System   System
 	LatestIR *IncidentReport
 	IRs      []*IncidentReport
+	Status   SystemStatus
+	Stats    SystemStats
+}
+
+// SystemStatus is the current status of a system based on its open incidents.
+type SystemStatus struct {
+	// Operational is true when the system doesn't have open incidents with impact.
+	Operational bool
+	// Impact is the worst impact of the open incidents.
+	Impact IncidentImpact
+	// OpenIRIDs are the IDs of the open incidents that affect the system.
+	OpenIRIDs []string
+}
+
+type SystemStats struct {
+	TotalIRs int
+	MTTR     time.Duration
 }
 
 type UIStats struct {
@@ -20,9 +37,53 @@ type UIStats struct {
 
 // UI represents the all the details a UI requires to be generated.
 type UI struct {
+	// Status is the current global status based on all the open incidents.
+	Status        SystemStatus
 	Stats         UIStats
 	Settings      StatusPageSettings
 	SystemDetails []SystemDetails
 	History       []*IncidentReport
 	OpenedIRs     []*IncidentReport
+	Pages         []Page
+}
+
+// Localized returns a copy of the UI with the incidents in the required language.
+func (u UI) Localized(lang string) UI {
+	irs := map[*IncidentReport]*IncidentReport{}
+	localize := func(ir *IncidentReport) *IncidentReport {
+		if ir == nil {
+			return nil
+		}
+		if l, ok := irs[ir]; ok {
+			return l
+		}
+		l := ir.Localized(lang)
+		irs[ir] = &l
+		return &l
+	}
+	localizeAll := func(irs []*IncidentReport) []*IncidentReport {
+		if irs == nil {
+			return nil
+		}
+		lirs := make([]*IncidentReport, 0, len(irs))
+		for _, ir := range irs {
+			lirs = append(lirs, localize(ir))
+		}
+		return lirs
+	}
+
+	u.History = localizeAll(u.History)
+	u.OpenedIRs = localizeAll(u.OpenedIRs)
+	sds := make([]SystemDetails, 0, len(u.SystemDetails))
+	for _, sd := range u.SystemDetails {
+		sd.LatestIR = localize(sd.LatestIR)
+		sd.IRs = localizeAll(sd.IRs)
+		sds = append(sds, sd)
+	}
+	if u.SystemDetails == nil {
+		sds = nil
+	}
+	u.SystemDetails = sds
+
+	return u
 }
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Workspace is a set of status pages that are generated from the same repository and
+// share the incidents.
+type Workspace struct {
+	// IncidentsPath is the directory of the shared incidents.
+	IncidentsPath string
+	Pages         []WorkspacePage
+}
+
+// WorkspacePage is a status page of a workspace.
+type WorkspacePage struct {
+	ID              string
+	StactusFilePath string
+	OutPath         string // If empty, the ID will be used.
+}
+
+// DefaultWorkspaceIncidentsPath is the shared incidents directory when the workspace doesn't set any.
+const DefaultWorkspaceIncidentsPath = "incidents"
+
+func (w *Workspace) Validate() error {
+	w.IncidentsPath = strings.TrimSpace(w.IncidentsPath)
+	if w.IncidentsPath == "" {
+		w.IncidentsPath = DefaultWorkspaceIncidentsPath
+	}
+
+	if len(w.Pages) == 0 {
+		return fmt.Errorf("at least 1 page is required")
+	}
+
+	ids := map[string]bool{}
+	for i := range w.Pages {
+		err := w.Pages[i].Validate()
+		if err != nil {
+			return fmt.Errorf("invalid page: %w", err)
+		}
+
+		id := w.Pages[i].ID
+		if ids[id] {
+			return fmt.Errorf("page %q is repeated", id)
+		}
+		ids[id] = true
+	}
+
+	// The pages are generated in parallel, they can't write on the same directories.
+	for i, p1 := range w.Pages {
+		for _, p2 := range w.Pages[i+1:] {
+			if outPathsOverlap(p1.OutPath, p2.OutPath) {
+				return fmt.Errorf("pages %q and %q out paths overlap (%q and %q)", p1.ID, p2.ID, p1.OutPath, p2.OutPath)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *WorkspacePage) Validate() error {
+	if p.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+
+	// The ID is used as URL path, same restrictions as the page slugs.
+	if !pageSlugRe.MatchString(p.ID) {
+		return fmt.Errorf("id %q is invalid, only lowercase alphanumeric, '-' and '_' are allowed", p.ID)
+	}
+
+	p.StactusFilePath = strings.TrimSpace(p.StactusFilePath)
+	if p.StactusFilePath == "" {
+		return fmt.Errorf("stactus file is required")
+	}
+
+	p.OutPath = strings.TrimSpace(p.OutPath)
+	if p.OutPath == "" {
+		p.OutPath = p.ID
+	}
+
+	// The out path is relative to the workspace out directory and can't escape from it.
+	if filepath.IsAbs(p.OutPath) || path.IsAbs(filepath.ToSlash(p.OutPath)) {
+		return fmt.Errorf("out path %q can't be absolute", p.OutPath)
+	}
+	p.OutPath = path.Clean(filepath.ToSlash(p.OutPath))
+	if p.OutPath == "." || p.OutPath == ".." || strings.HasPrefix(p.OutPath, "../") {
+		return fmt.Errorf("out path %q must be a directory inside the out directory", p.OutPath)
+	}
+
+	return nil
+}
+
+// outPathsOverlap returns true if the out paths are the same or one is inside the other.
+func outPathsOverlap(a, b string) bool {
+	return a == b || strings.HasPrefix(a, b+"/") || strings.HasPrefix(b, a+"/")
+}
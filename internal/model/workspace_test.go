@@ -0,0 +1,180 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/slok/stactus/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func getBaseWorkspace() model.Workspace {
+	return model.Workspace{
+		IncidentsPath: "shared/incidents",
+		Pages: []model.WorkspacePage{
+			{ID: "product-a", StactusFilePath: "a/stactus.yaml", OutPath: "a"},
+			{ID: "product-b", StactusFilePath: "b/stactus.yaml", OutPath: "b"},
+		},
+	}
+}
+
+func TestWorkspaceValidate(t *testing.T) {
+	tests := map[string]struct {
+		workspace    func() model.Workspace
+		expWorkspace func() model.Workspace
+		expErr       bool
+	}{
+		"A correct workspace should validate correctly.": {
+			workspace:    getBaseWorkspace,
+			expWorkspace: getBaseWorkspace,
+		},
+
+		"A missing incidents path and page out paths should be defaulted.": {
+			workspace: func() model.Workspace {
+				w := getBaseWorkspace()
+				w.IncidentsPath = ""
+				w.Pages[0].OutPath = ""
+				return w
+			},
+			expWorkspace: func() model.Workspace {
+				w := getBaseWorkspace()
+				w.IncidentsPath = "incidents"
+				w.Pages[0].OutPath = "product-a"
+				return w
+			},
+		},
+
+		"A workspace without pages should fail.": {
+			workspace: func() model.Workspace {
+				w := getBaseWorkspace()
+				w.Pages = nil
+				return w
+			},
+			expErr: true,
+		},
+
+		"A page without ID should fail.": {
+			workspace: func() model.Workspace {
+				w := getBaseWorkspace()
+				w.Pages[0].ID = ""
+				return w
+			},
+			expErr: true,
+		},
+
+		"A page ID with invalid URL chars should fail.": {
+			workspace: func() model.Workspace {
+				w := getBaseWorkspace()
+				w.Pages[0].ID = "product/a"
+				return w
+			},
+			expErr: true,
+		},
+
+		"A page without stactus file should fail.": {
+			workspace: func() model.Workspace {
+				w := getBaseWorkspace()
+				w.Pages[0].StactusFilePath = ""
+				return w
+			},
+			expErr: true,
+		},
+
+		"Page out paths should be cleaned.": {
+			workspace: func() model.Workspace {
+				w := getBaseWorkspace()
+				w.Pages[0].OutPath = " ./pages//a/ "
+				return w
+			},
+			expWorkspace: func() model.Workspace {
+				w := getBaseWorkspace()
+				w.Pages[0].OutPath = "pages/a"
+				return w
+			},
+		},
+
+		"Repeated page out paths should fail.": {
+			workspace: func() model.Workspace {
+				w := getBaseWorkspace()
+				w.Pages[1].OutPath = "a"
+				return w
+			},
+			expErr: true,
+		},
+
+		"Repeated page out paths after cleaning should fail.": {
+			workspace: func() model.Workspace {
+				w := getBaseWorkspace()
+				w.Pages[1].OutPath = "./a/"
+				return w
+			},
+			expErr: true,
+		},
+
+		"Nested page out paths should fail.": {
+			workspace: func() model.Workspace {
+				w := getBaseWorkspace()
+				w.Pages[1].OutPath = "a/b"
+				return w
+			},
+			expErr: true,
+		},
+
+		"A page out path outside the out directory should fail.": {
+			workspace: func() model.Workspace {
+				w := getBaseWorkspace()
+				w.Pages[0].OutPath = "../a"
+				return w
+			},
+			expErr: true,
+		},
+
+		"A page out path escaping after cleaning should fail.": {
+			workspace: func() model.Workspace {
+				w := getBaseWorkspace()
+				w.Pages[0].OutPath = "a/../../b"
+				return w
+			},
+			expErr: true,
+		},
+
+		"A page out path on the out directory root should fail.": {
+			workspace: func() model.Workspace {
+				w := getBaseWorkspace()
+				w.Pages[0].OutPath = "."
+				return w
+			},
+			expErr: true,
+		},
+
+		"An absolute page out path should fail.": {
+			workspace: func() model.Workspace {
+				w := getBaseWorkspace()
+				w.Pages[0].OutPath = "/var/www/a"
+				return w
+			},
+			expErr: true,
+		},
+
+		"Repeated page IDs should fail.": {
+			workspace: func() model.Workspace {
+				w := getBaseWorkspace()
+				w.Pages[1].ID = "product-a"
+				return w
+			},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			w := test.workspace()
+			err := w.Validate()
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expWorkspace(), w)
+			}
+		})
+	}
+}
@@ -181,7 +181,7 @@ func NewJSONStatusPageRepository(componentsRawJSON string, incidentsRawJSON stri
 		return nil, fmt.Errorf("invalid settings: %w", err)
 	}
 
-	memRepo := storagememory.NewRepository(systems, settings, irs)
+	memRepo := storagememory.NewRepository(systems, settings, irs, nil)
 
 	return &memRepo, nil
 }
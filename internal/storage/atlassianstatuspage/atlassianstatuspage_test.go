@@ -36,13 +36,14 @@ func TestNewJSONStatusPageRepository(t *testing.T) {
 			},
 			expIncidents: []model.IncidentReport{
 				{
-					ID:        "69sb0f8lydp4",
-					Name:      "Incident with Pages and Actions",
-					SystemIDs: []string{"br0l2tvcx85d", "vg70hn9s2tyj"},
-					Impact:    model.IncidentImpactMajor,
-					Start:     time.Date(2024, time.September, 16, 21, 31, 2, 798000000, time.UTC),
-					End:       time.Date(2024, time.September, 16, 22, 8, 39, 333000000, time.UTC),
-					Duration:  2256535000000 * time.Nanosecond,
+					ID:         "69sb0f8lydp4",
+					Name:       "Incident with Pages and Actions",
+					SystemIDs:  []string{"br0l2tvcx85d", "vg70hn9s2tyj"},
+					Impact:     model.IncidentImpactMajor,
+					Visibility: model.IncidentVisibilityPublic,
+					Start:      time.Date(2024, time.September, 16, 21, 31, 2, 798000000, time.UTC),
+					End:        time.Date(2024, time.September, 16, 22, 8, 39, 333000000, time.UTC),
+					Duration:   2256535000000 * time.Nanosecond,
 					Timeline: []model.IncidentReportEvent{
 						{
 							Description: "This incident has been resolved.",
@@ -78,13 +79,14 @@ func TestNewJSONStatusPageRepository(t *testing.T) {
 				},
 
 				{
-					ID:        "r3x7x31k7nn1",
-					Name:      "Disruption with Git SSH",
-					SystemIDs: []string{},
-					Impact:    model.IncidentImpactMinor,
-					Start:     time.Date(2024, time.September, 16, 13, 29, 47, 204000000, time.UTC),
-					End:       time.Date(2024, time.September, 16, 14, 28, 3, 812000000, time.UTC),
-					Duration:  3496608000000 * time.Nanosecond,
+					ID:         "r3x7x31k7nn1",
+					Name:       "Disruption with Git SSH",
+					SystemIDs:  []string{},
+					Impact:     model.IncidentImpactMinor,
+					Visibility: model.IncidentVisibilityPublic,
+					Start:      time.Date(2024, time.September, 16, 13, 29, 47, 204000000, time.UTC),
+					End:        time.Date(2024, time.September, 16, 14, 28, 3, 812000000, time.UTC),
+					Duration:   3496608000000 * time.Nanosecond,
 					Timeline: []model.IncidentReportEvent{
 						{
 							Description: "This incident has been resolved.",
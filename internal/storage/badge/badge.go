@@ -0,0 +1,202 @@
+package badge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/slok/stactus/internal/conventions"
+	"github.com/slok/stactus/internal/model"
+	utilfs "github.com/slok/stactus/internal/util/fs"
+)
+
+type RepositoryConfig struct {
+	FileManager utilfs.FileManager
+	OutPath     string
+}
+
+func (c *RepositoryConfig) defaults() error {
+	if c.FileManager == nil {
+		c.FileManager = utilfs.StdFileManager
+	}
+
+	if c.OutPath == "" {
+		return fmt.Errorf("out path is required")
+	}
+	c.OutPath = filepath.Clean(c.OutPath)
+
+	return nil
+}
+
+// Repository knows how to create static status badges, these are Shields.io endpoint
+// JSON badges (https://shields.io/badges/endpoint-badge) and self rendered SVG badges.
+type Repository struct {
+	fileManager utilfs.FileManager
+	outPath     string
+}
+
+func NewFSRepository(config RepositoryConfig) (*Repository, error) {
+	err := config.defaults()
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return &Repository{
+		fileManager: config.FileManager,
+		outPath:     config.OutPath,
+	}, nil
+}
+
+func (r Repository) CreateBadges(ctx context.Context, ui model.UI) error {
+	// Global badge.
+	err := r.writeBadge(ctx, newBadge(ui.Settings.Name, ui.Status),
+		conventions.StatusBadgeFilePath(r.outPath, conventions.BadgeShieldsExt),
+		conventions.StatusBadgeFilePath(r.outPath, conventions.BadgeSVGExt),
+	)
+	if err != nil {
+		return fmt.Errorf("could not write status badge: %w", err)
+	}
+
+	// Per system badges.
+	for _, s := range ui.SystemDetails {
+		err := r.writeBadge(ctx, newBadge(s.System.Name, s.Status),
+			conventions.SystemBadgeFilePath(r.outPath, s.System.ID, conventions.BadgeShieldsExt),
+			conventions.SystemBadgeFilePath(r.outPath, s.System.ID, conventions.BadgeSVGExt),
+		)
+		if err != nil {
+			return fmt.Errorf("could not write %q system badge: %w", s.System.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (r Repository) writeBadge(ctx context.Context, b badge, shieldsPath, svgPath string) error {
+	shields, err := json.Marshal(shieldsEndpoint{
+		SchemaVersion: 1,
+		Label:         b.Label,
+		Message:       b.Message,
+		Color:         b.Color,
+	})
+	if err != nil {
+		return fmt.Errorf("could not render Shields.io endpoint badge: %w", err)
+	}
+
+	err = r.fileManager.WriteFile(ctx, shieldsPath, shields)
+	if err != nil {
+		return fmt.Errorf("could not write Shields.io endpoint badge: %w", err)
+	}
+
+	svg, err := renderSVG(b)
+	if err != nil {
+		return fmt.Errorf("could not render SVG badge: %w", err)
+	}
+
+	err = r.fileManager.WriteFile(ctx, svgPath, svg)
+	if err != nil {
+		return fmt.Errorf("could not write SVG badge: %w", err)
+	}
+
+	return nil
+}
+
+type badge struct {
+	Label   string
+	Message string
+	Color   string // Shields.io named color.
+}
+
+func newBadge(label string, status model.SystemStatus) badge {
+	b := badge{Label: label}
+	switch {
+	case status.Operational:
+		b.Message, b.Color = "operational", "brightgreen"
+	case status.Impact == model.IncidentImpactCritical:
+		b.Message, b.Color = "major outage", "red"
+	case status.Impact == model.IncidentImpactMajor:
+		b.Message, b.Color = "partial outage", "orange"
+	default:
+		b.Message, b.Color = "degraded", "yellow"
+	}
+
+	return b
+}
+
+// shieldsEndpoint is the Shields.io endpoint badge schema.
+type shieldsEndpoint struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// Same colors as Shields.io named colors.
+var svgColors = map[string]string{
+	"brightgreen": "#4c1",
+	"yellow":      "#dfb317",
+	"orange":      "#fe7d37",
+	"red":         "#e05d44",
+}
+
+const (
+	svgCharWidth = 7 // Approximate width of a Verdana 11px char.
+	svgPadding   = 10
+)
+
+func renderSVG(b badge) ([]byte, error) {
+	labelWidth := utf8.RuneCountInString(b.Label)*svgCharWidth + svgPadding
+	messageWidth := utf8.RuneCountInString(b.Message)*svgCharWidth + svgPadding
+
+	data := svgTplData{
+		Label:        b.Label,
+		Message:      b.Message,
+		Color:        svgColors[b.Color],
+		Width:        labelWidth + messageWidth,
+		LabelWidth:   labelWidth,
+		MessageWidth: messageWidth,
+		LabelX:       float64(labelWidth) / 2,
+		MessageX:     float64(labelWidth) + float64(messageWidth)/2,
+	}
+
+	var buf bytes.Buffer
+	err := svgTpl.Execute(&buf, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+type svgTplData struct {
+	Label        string
+	Message      string
+	Color        string
+	Width        int
+	LabelWidth   int
+	MessageWidth int
+	LabelX       float64
+	MessageX     float64
+}
+
+// Based on Shields.io flat style.
+var svgTpl = template.Must(template.New("").Parse(`<svg xmlns="http://www.w3.org/2000/svg" width="{{ .Width }}" height="20" role="img" aria-label="{{ html .Label }}: {{ html .Message }}">
+  <title>{{ html .Label }}: {{ html .Message }}</title>
+  <linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>
+  <clipPath id="r"><rect width="{{ .Width }}" height="20" rx="3" fill="#fff"/></clipPath>
+  <g clip-path="url(#r)">
+    <rect width="{{ .LabelWidth }}" height="20" fill="#555"/>
+    <rect x="{{ .LabelWidth }}" width="{{ .MessageWidth }}" height="20" fill="{{ .Color }}"/>
+    <rect width="{{ .Width }}" height="20" fill="url(#s)"/>
+  </g>
+  <g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+    <text x="{{ .LabelX }}" y="15" fill="#010101" fill-opacity=".3">{{ html .Label }}</text>
+    <text x="{{ .LabelX }}" y="14">{{ html .Label }}</text>
+    <text x="{{ .MessageX }}" y="15" fill="#010101" fill-opacity=".3">{{ html .Message }}</text>
+    <text x="{{ .MessageX }}" y="14">{{ html .Message }}</text>
+  </g>
+</svg>
+`))
@@ -0,0 +1,115 @@
+package badge_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slok/stactus/internal/model"
+	"github.com/slok/stactus/internal/storage/badge"
+	utilfs "github.com/slok/stactus/internal/util/fs"
+)
+
+func TestRepositoryCreateBadges(t *testing.T) {
+	tests := map[string]struct {
+		ui        func() model.UI
+		expBadges map[string]string
+		expErr    bool
+	}{
+		"Operational systems should render operational badges.": {
+			ui: func() model.UI {
+				return model.UI{
+					Settings: model.StatusPageSettings{Name: "Test"},
+					Status:   model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
+					SystemDetails: []model.SystemDetails{
+						{
+							System: model.System{ID: "s1", Name: "API"},
+							Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
+						},
+					},
+				}
+			},
+			expBadges: map[string]string{
+				"test/badges/status.json":    `{"schemaVersion":1,"label":"Test","message":"operational","color":"brightgreen"}`,
+				"test/badges/system/s1.json": `{"schemaVersion":1,"label":"API","message":"operational","color":"brightgreen"}`,
+				"test/badges/system/s1.svg": `
+<svg xmlns="http://www.w3.org/2000/svg" width="118" height="20" role="img" aria-label="API: operational">
+  <title>API: operational</title>
+  <linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>
+  <clipPath id="r"><rect width="118" height="20" rx="3" fill="#fff"/></clipPath>
+  <g clip-path="url(#r)">
+    <rect width="31" height="20" fill="#555"/>
+    <rect x="31" width="87" height="20" fill="#4c1"/>
+    <rect width="118" height="20" fill="url(#s)"/>
+  </g>
+  <g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+    <text x="15.5" y="15" fill="#010101" fill-opacity=".3">API</text>
+    <text x="15.5" y="14">API</text>
+    <text x="74.5" y="15" fill="#010101" fill-opacity=".3">operational</text>
+    <text x="74.5" y="14">operational</text>
+  </g>
+</svg>
+`,
+			},
+		},
+
+		"Systems with open incidents should render the badges based on the impact.": {
+			ui: func() model.UI {
+				return model.UI{
+					Settings: model.StatusPageSettings{Name: "Test"},
+					Status:   model.SystemStatus{Impact: model.IncidentImpactCritical, OpenIRIDs: []string{"ir1", "ir2"}},
+					SystemDetails: []model.SystemDetails{
+						{
+							System: model.System{ID: "s1", Name: "S1"},
+							Status: model.SystemStatus{Impact: model.IncidentImpactNone, OpenIRIDs: []string{"ir0"}},
+						},
+						{
+							System: model.System{ID: "s2", Name: "S2"},
+							Status: model.SystemStatus{Impact: model.IncidentImpactMinor, OpenIRIDs: []string{"ir1"}},
+						},
+						{
+							System: model.System{ID: "s3", Name: "S3"},
+							Status: model.SystemStatus{Impact: model.IncidentImpactMajor, OpenIRIDs: []string{"ir3"}},
+						},
+						{
+							System: model.System{ID: "s4", Name: "S4"},
+							Status: model.SystemStatus{Impact: model.IncidentImpactCritical, OpenIRIDs: []string{"ir2"}},
+						},
+					},
+				}
+			},
+			expBadges: map[string]string{
+				"test/badges/status.json":    `{"schemaVersion":1,"label":"Test","message":"major outage","color":"red"}`,
+				"test/badges/system/s1.json": `{"schemaVersion":1,"label":"S1","message":"degraded","color":"yellow"}`,
+				"test/badges/system/s2.json": `{"schemaVersion":1,"label":"S2","message":"degraded","color":"yellow"}`,
+				"test/badges/system/s3.json": `{"schemaVersion":1,"label":"S3","message":"partial outage","color":"orange"}`,
+				"test/badges/system/s4.json": `{"schemaVersion":1,"label":"S4","message":"major outage","color":"red"}`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			fsm := utilfs.NewTestFileManager()
+			repo, err := badge.NewFSRepository(badge.RepositoryConfig{
+				FileManager: fsm,
+				OutPath:     "test",
+			})
+			require.NoError(err)
+
+			err = repo.CreateBadges(context.TODO(), test.ui())
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				for k, v := range test.expBadges {
+					fsm.AssertEqual(t, k, v)
+				}
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"html/template"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -19,7 +20,7 @@ import (
 
 type RepositoryConfig struct {
 	FileManager         utilfs.FileManager
-	AtomHistoryFilePath string
+	OutPath             string
 	HistoryItemsPerFeed int
 	TimeNow             func() time.Time
 }
@@ -29,13 +30,13 @@ func (c *RepositoryConfig) defaults() error {
 		c.FileManager = utilfs.StdFileManager
 	}
 
-	c.AtomHistoryFilePath = filepath.Clean(c.AtomHistoryFilePath)
-	if c.AtomHistoryFilePath == "" {
-		return fmt.Errorf("atom history feed file path is required")
+	if c.OutPath == "" {
+		return fmt.Errorf("out path is required")
 	}
+	c.OutPath = filepath.Clean(c.OutPath)
 
-	if !strings.HasSuffix(c.AtomHistoryFilePath, conventions.IRHistoryAtomFeedPathName) {
-		return fmt.Errorf("atom history feed must end with %q path", conventions.IRHistoryAtomFeedPathName)
+	if c.HistoryItemsPerFeed < 0 {
+		return fmt.Errorf("history items per feed can't be negative")
 	}
 
 	if c.HistoryItemsPerFeed == 0 {
@@ -51,7 +52,7 @@ func (c *RepositoryConfig) defaults() error {
 
 type Repository struct {
 	fileManager         utilfs.FileManager
-	atomHistoryFilePath string
+	outPath             string
 	historyItemsPerFeed int
 	timeNow             func() time.Time
 }
@@ -64,74 +65,299 @@ func NewFSRepository(config RepositoryConfig) (*Repository, error) {
 
 	return &Repository{
 		fileManager:         config.FileManager,
-		atomHistoryFilePath: config.AtomHistoryFilePath,
+		outPath:             config.OutPath,
 		historyItemsPerFeed: config.HistoryItemsPerFeed,
 		timeNow:             config.TimeNow,
 	}, nil
 }
 
 func (r Repository) CreateHistoryFeed(ctx context.Context, ui model.UI) error {
+	markdown, err := newMarkdownRenderer(ui.Settings)
+	if err != nil {
+		return fmt.Errorf("could not create markdown renderer: %w", err)
+	}
+
+	// Global feed.
+	feed, err := r.newHistoryFeed(
+		fmt.Sprintf("%s - Incident history", ui.Settings.Name),
+		fmt.Sprintf("%s status page", ui.Settings.Name),
+		ui.Settings.URL,
+		ui,
+		ui.History,
+		markdown,
+	)
+	if err != nil {
+		return fmt.Errorf("could not create history feed: %w", err)
+	}
+
+	err = r.writeFeed(ctx, ui.Settings.Feed, feed, func(feedPathName string) string {
+		return filepath.Join(r.outPath, feedPathName)
+	})
+	if err != nil {
+		return fmt.Errorf("could not write history feed: %w", err)
+	}
+
+	// Per system feeds.
+	for _, s := range ui.SystemDetails {
+		feed, err := r.newHistoryFeed(
+			fmt.Sprintf("%s - %s incident history", ui.Settings.Name, s.System.Name),
+			fmt.Sprintf("%s status page (%s)", ui.Settings.Name, s.System.Name),
+			conventions.SystemDetailURL(ui.Settings.URL, s.System.ID),
+			ui,
+			s.IRs,
+			markdown,
+		)
+		if err != nil {
+			return fmt.Errorf("could not create %q system history feed: %w", s.System.ID, err)
+		}
+
+		err = r.writeFeed(ctx, ui.Settings.Feed, feed, func(feedPathName string) string {
+			return conventions.SystemIRHistoryFeedFilePath(r.outPath, s.System.ID, feedPathName)
+		})
+		if err != nil {
+			return fmt.Errorf("could not write %q system history feed: %w", s.System.ID, err)
+		}
+	}
+
+	// TODO(slok): Slack RSS: To receive live status updates in Slack, copy and paste the text below into the Slack channel of your choice.: `/feed subscribe https://linearstatus.com/slack.rss`
+
+	return nil
+}
+
+func (r Repository) newHistoryFeed(title, description, url string, ui model.UI, history []*model.IncidentReport, markdown *utilhtml.MarkdownRenderer) (*feeds.Feed, error) {
 	now := r.timeNow()
 	feed := &feeds.Feed{
-		Title:       fmt.Sprintf("%s - Incident history", ui.Settings.Name),
-		Link:        &feeds.Link{Rel: "alternate", Type: "text/html", Href: ui.Settings.URL},
-		Description: fmt.Sprintf("%s status page", ui.Settings.Name),
+		Title:       title,
+		Link:        &feeds.Link{Rel: "alternate", Type: "text/html", Href: url},
+		Description: description,
 		Author:      &feeds.Author{Name: ui.Settings.Name},
 		Updated:     now,
-		Id:          ui.Settings.URL,
+		Id:          url,
+	}
+
+	var items []*feeds.Item
+	var err error
+	switch ui.Settings.Feed.Mode {
+	case model.FeedModeUpdate:
+		items, err = r.newUpdateFeedItems(ui, history, markdown)
+	default:
+		items, err = r.newIncidentFeedItems(ui, history, markdown)
 	}
+	if err != nil {
+		return nil, err
+	}
+	feed.Items = items
+
+	return feed, nil
+}
 
-	history := ui.History
-	if len(ui.History) > r.historyItemsPerFeed {
-		history = ui.History[:r.historyItemsPerFeed]
+// newIncidentFeedItems returns an item per incident, updated with the latest incident event.
+func (r Repository) newIncidentFeedItems(ui model.UI, history []*model.IncidentReport, markdown *utilhtml.MarkdownRenderer) ([]*feeds.Item, error) {
+	if len(history) > r.historyItemsPerFeed {
+		history = history[:r.historyItemsPerFeed]
 	}
 
+	items := []*feeds.Item{}
 	for _, ir := range history {
 		if len(ir.Timeline) < 1 {
 			continue
 		}
 
-		var b bytes.Buffer
-		data := []irHTMLItemData{}
-		for _, e := range ir.Timeline {
-			md, err := utilhtml.RenderMarkdownToHTML(e.Description)
-			if err != nil {
-				return fmt.Errorf("could not render markdown: %w", err)
+		content, err := renderIRHTMLItems(markdown, ui.Settings.URL, ir.Timeline)
+		if err != nil {
+			return nil, err
+		}
+
+		url := conventions.IRDetailURL(ui.Settings.URL, ir.ID)
+		updated := ir.Timeline[0].TS // Latest.
+
+		// Published postmortems are an update of the incident too.
+		if ir.Postmortem != nil {
+			content = renderPostmortemHTMLItem(url) + content
+			if ir.Postmortem.TS.After(updated) {
+				updated = ir.Postmortem.TS
 			}
+		}
+
+		items = append(items, &feeds.Item{
+			Title:   ir.Name,
+			Link:    &feeds.Link{Rel: "alternate", Type: "text/html", Href: url},
+			Content: content,
+			Created: ir.Start,
+			Updated: updated,
+			Id:      url,
+		})
+	}
+
+	return items, nil
+}
+
+// newUpdateFeedItems returns an item per incident event (update stream) sorted by event time, this way
+// the readers that don't resurface updated entries will notify each of the incident updates.
+func (r Repository) newUpdateFeedItems(ui model.UI, history []*model.IncidentReport, markdown *utilhtml.MarkdownRenderer) ([]*feeds.Item, error) {
+	type irEvent struct {
+		ir         *model.IncidentReport
+		e          model.IncidentReportEvent
+		postmortem bool // The postmortem publication, only the event TS is set.
+		// seq is the number of older events of the incident with the same TS, so their IDs don't collide.
+		seq int
+	}
 
-			data = append(data, irHTMLItemData{
-				Kind:        string(e.Kind),
-				Description: md,
-				TS:          e.TS.UTC().Format(time.RFC3339),
+	events := []irEvent{}
+	for _, ir := range history {
+		sameTS := map[time.Time]int{}
+		for i := len(ir.Timeline) - 1; i >= 0; i-- {
+			e := ir.Timeline[i]
+			ts := e.TS.UTC()
+			events = append(events, irEvent{ir: ir, e: e, seq: sameTS[ts]})
+			sameTS[ts]++
+		}
+		if ir.Postmortem != nil {
+			events = append(events, irEvent{ir: ir, e: model.IncidentReportEvent{TS: ir.Postmortem.TS}, postmortem: true})
+		}
+	}
+
+	// Latest first.
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].e.TS.Equal(events[j].e.TS) {
+			if events[i].ir.ID == events[j].ir.ID {
+				return events[i].seq > events[j].seq
+			}
+			return events[i].ir.ID < events[j].ir.ID
+		}
+		return events[i].e.TS.After(events[j].e.TS)
+	})
+
+	if len(events) > r.historyItemsPerFeed {
+		events = events[:r.historyItemsPerFeed]
+	}
+
+	items := []*feeds.Item{}
+	for _, ev := range events {
+		url := conventions.IRDetailURL(ui.Settings.URL, ev.ir.ID)
+		if ev.postmortem {
+			items = append(items, &feeds.Item{
+				Title:   fmt.Sprintf("[%s] %s", postmortemPublishedTitle, ev.ir.Name),
+				Link:    &feeds.Link{Rel: "alternate", Type: "text/html", Href: url + "#postmortem"},
+				Content: renderPostmortemHTMLItem(url),
+				Created: ev.e.TS,
+				Updated: ev.e.TS,
+				Id:      url + "#postmortem",
 			})
+			continue
 		}
 
-		err := irHTMLItemsTpl.Execute(&b, data)
+		content, err := renderIRHTMLItems(markdown, ui.Settings.URL, []model.IncidentReportEvent{ev.e})
 		if err != nil {
-			return fmt.Errorf("could not render Atom entry content: %w", err)
+			return nil, err
 		}
 
-		url := conventions.IRDetailURL(ui.Settings.URL, ir.ID)
-		feed.Add(&feeds.Item{
-			Title:   ir.Name,
+		// Stable ID based on the IR and the event.
+		id := fmt.Sprintf("%s#update-%s", url, ev.e.TS.UTC().Format(time.RFC3339))
+		if ev.seq > 0 {
+			id = fmt.Sprintf("%s-%d", id, ev.seq+1)
+		}
+
+		items = append(items, &feeds.Item{
+			Title:   fmt.Sprintf("[%s] %s", updateKindTitle(ev.e.Kind), ev.ir.Name),
 			Link:    &feeds.Link{Rel: "alternate", Type: "text/html", Href: url},
-			Content: b.String(),
-			Created: ir.Start,
-			Updated: ir.Timeline[0].TS, // Latest.
-			Id:      url,
+			Content: content,
+			Created: ev.e.TS,
+			Updated: ev.e.TS,
+			Id:      id,
 		})
 	}
 
-	atomFeed, err := feed.ToAtom()
-	if err != nil {
-		return fmt.Errorf("could not render Atom feed: %w", err)
+	return items, nil
+}
+
+const postmortemPublishedTitle = "Postmortem published"
+
+// renderPostmortemHTMLItem returns the feed entry content that flags the incident postmortem.
+func renderPostmortemHTMLItem(irURL string) string {
+	return fmt.Sprintf(`<p><strong><a href="%s#postmortem">%s</a></strong></p>`, template.HTMLEscapeString(irURL), postmortemPublishedTitle)
+}
+
+func updateKindTitle(k model.IncidentUpdateKind) string {
+	if k == "" {
+		k = model.IncidentUpdateKindUpdate
 	}
-	err = r.fileManager.WriteFile(ctx, r.atomHistoryFilePath, []byte(atomFeed))
+
+	return strings.ToUpper(string(k[:1])) + string(k[1:])
+}
+
+func renderIRHTMLItems(markdown *utilhtml.MarkdownRenderer, siteURL string, timeline []model.IncidentReportEvent) (string, error) {
+	data := []irHTMLItemData{}
+	for _, e := range timeline {
+		md, err := markdown.RenderToHTML(e.Description, utilhtml.AssetURLResolver(siteURL, e.Assets))
+		if err != nil {
+			return "", fmt.Errorf("could not render markdown: %w", err)
+		}
+
+		data = append(data, irHTMLItemData{
+			Kind:        string(e.Kind),
+			Description: md,
+			TS:          e.TS.UTC().Format(time.RFC3339),
+		})
+	}
+
+	var b bytes.Buffer
+	err := irHTMLItemsTpl.Execute(&b, data)
 	if err != nil {
-		return fmt.Errorf("could not write Atom feed: %w", err)
+		return "", fmt.Errorf("could not render feed entry content: %w", err)
 	}
 
-	// TODO(slok): Slack RSS: To receive live status updates in Slack, copy and paste the text below into the Slack channel of your choice.: `/feed subscribe https://linearstatus.com/slack.rss`
+	return b.String(), nil
+}
+
+// newMarkdownRenderer returns the renderer of the Markdown contents based on the status page settings.
+func newMarkdownRenderer(settings model.StatusPageSettings) (*utilhtml.MarkdownRenderer, error) {
+	return utilhtml.NewMarkdownRenderer(utilhtml.MarkdownRendererConfig{
+		DisableTables:        settings.Markdown.DisableTables,
+		DisableStrikethrough: settings.Markdown.DisableStrikethrough,
+		DisableAutolinks:     settings.Markdown.DisableAutolinks,
+		DisableTaskLists:     settings.Markdown.DisableTaskLists,
+		DisableFootnotes:     settings.Markdown.DisableFootnotes,
+		RawHTML:              settings.Markdown.RawHTML,
+		HeadingAnchors:       settings.Markdown.HeadingAnchors,
+		CodeHighlightStyle:   settings.Markdown.CodeHighlightStyle,
+		SiteURL:              settings.URL,
+		ExternalLinkRel:      settings.Markdown.ExternalLinkRel,
+		ExternalLinkTarget:   settings.Markdown.ExternalLinkTarget,
+	})
+}
+
+// writeFeed will write the same feed in all the enabled formats, the path of each format
+// is obtained with the filePath func based on the format feed path name.
+func (r Repository) writeFeed(ctx context.Context, settings model.FeedSettings, feed *feeds.Feed, filePath func(feedPathName string) string) error {
+	type format struct {
+		disabled     bool
+		name         string
+		feedPathName string
+		render       func() (string, error)
+	}
+
+	formats := []format{
+		{disabled: settings.DisableAtom, name: "Atom", feedPathName: conventions.IRHistoryAtomFeedPathName, render: feed.ToAtom},
+		{disabled: settings.DisableRSS, name: "RSS", feedPathName: conventions.IRHistoryRSSFeedPathName, render: feed.ToRss},
+		{disabled: settings.DisableJSON, name: "JSON", feedPathName: conventions.IRHistoryJSONFeedPathName, render: feed.ToJSON},
+	}
+
+	for _, f := range formats {
+		if f.disabled {
+			continue
+		}
+
+		data, err := f.render()
+		if err != nil {
+			return fmt.Errorf("could not render %s feed: %w", f.name, err)
+		}
+
+		err = r.fileManager.WriteFile(ctx, filePath(f.feedPathName), []byte(data))
+		if err != nil {
+			return fmt.Errorf("could not write %s feed: %w", f.name, err)
+		}
+	}
 
 	return nil
 }
@@ -16,9 +16,11 @@ func TestRepositoryCreateHistoryFeed(t *testing.T) {
 	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
 
 	tests := map[string]struct {
-		ui       func() model.UI
-		expFeeds map[string]string
-		expErr   bool
+		historyItems   int
+		ui             func() model.UI
+		expFeeds       map[string]string
+		expNotExistent []string
+		expErr         bool
 	}{
 		"Correct data should render correctly the metrics": {
 			ui: func() model.UI {
@@ -61,7 +63,7 @@ func TestRepositoryCreateHistoryFeed(t *testing.T) {
     <title>IR 3</title>
     <updated>1912-06-23T02:52:03Z</updated>
     <id>https://status.slok.dev/ir/ir3</id>
-    <content type="html">&#xA;&#xA;&lt;p&gt;&#xA;    &lt;small&gt;1912-06-23T02:52:03Z&lt;/small&gt;&#xA;    &lt;br /&gt;&#xA;    &lt;strong&gt;update&lt;/strong&gt;&#xA;     - &lt;p&gt;d33&lt;/p&gt;&#xA;&#xA;&lt;/p&gt;&#xA;&#xA;&lt;p&gt;&#xA;    &lt;small&gt;1912-06-23T02:51:03Z&lt;/small&gt;&#xA;    &lt;br /&gt;&#xA;    &lt;strong&gt;update&lt;/strong&gt;&#xA;     - &lt;p&gt;d32&lt;/p&gt;&#xA;&#xA;&lt;/p&gt;&#xA;&#xA;&lt;p&gt;&#xA;    &lt;small&gt;1912-06-23T02:42:03Z&lt;/small&gt;&#xA;    &lt;br /&gt;&#xA;    &lt;strong&gt;investigating&lt;/strong&gt;&#xA;     - &lt;p&gt;&lt;a href=&#34;https://slok.dev&#34;&gt;d31&lt;/a&gt;&lt;/p&gt;&#xA;&#xA;&lt;/p&gt;&#xA;&#xA;</content>
+    <content type="html">&#xA;&#xA;&lt;p&gt;&#xA;    &lt;small&gt;1912-06-23T02:52:03Z&lt;/small&gt;&#xA;    &lt;br /&gt;&#xA;    &lt;strong&gt;update&lt;/strong&gt;&#xA;     - &lt;p&gt;d33&lt;/p&gt;&#xA;&#xA;&lt;/p&gt;&#xA;&#xA;&lt;p&gt;&#xA;    &lt;small&gt;1912-06-23T02:51:03Z&lt;/small&gt;&#xA;    &lt;br /&gt;&#xA;    &lt;strong&gt;update&lt;/strong&gt;&#xA;     - &lt;p&gt;d32&lt;/p&gt;&#xA;&#xA;&lt;/p&gt;&#xA;&#xA;&lt;p&gt;&#xA;    &lt;small&gt;1912-06-23T02:42:03Z&lt;/small&gt;&#xA;    &lt;br /&gt;&#xA;    &lt;strong&gt;investigating&lt;/strong&gt;&#xA;     - &lt;p&gt;&lt;a href=&#34;https://slok.dev&#34; rel=&#34;noopener noreferrer&#34;&gt;d31&lt;/a&gt;&lt;/p&gt;&#xA;&#xA;&lt;/p&gt;&#xA;&#xA;</content>
     <link href="https://status.slok.dev/ir/ir3" rel="alternate" type="text/html"></link>
   </entry>
   <entry>
@@ -81,6 +83,403 @@ func TestRepositoryCreateHistoryFeed(t *testing.T) {
 </feed>
 `},
 		},
+
+		"Each system should have its own feed only with the incidents of the system, limited by the max items.": {
+			historyItems: 1,
+			ui: func() model.UI {
+				ir1 := &model.IncidentReport{ID: "ir1", Name: "IR 1", SystemIDs: []string{"s1", "s2"}, Start: t0.Add(300 * time.Minute), End: t0.Add(320 * time.Minute), Duration: 20 * time.Minute, Timeline: []model.IncidentReportEvent{
+					{TS: t0.Add(320 * time.Minute), Description: "d12", Kind: model.IncidentUpdateKindResolved},
+				}}
+				ir2 := &model.IncidentReport{ID: "ir2", Name: "IR 2", SystemIDs: []string{"s2"}, Start: t0.Add(200 * time.Minute), End: t0.Add(220 * time.Minute), Duration: 20 * time.Minute, Timeline: []model.IncidentReportEvent{
+					{TS: t0.Add(220 * time.Minute), Description: "d24", Kind: model.IncidentUpdateKindResolved},
+				}}
+
+				return model.UI{
+					Settings: model.StatusPageSettings{
+						Name: "Test",
+						URL:  "https://status.slok.dev",
+					},
+					SystemDetails: []model.SystemDetails{
+						{System: model.System{ID: "s1", Name: "System 1"}, IRs: []*model.IncidentReport{ir1}},
+						{System: model.System{ID: "s2", Name: "System 2"}, IRs: []*model.IncidentReport{ir1, ir2}},
+						{System: model.System{ID: "s3", Name: "System 3"}},
+					},
+					History: []*model.IncidentReport{ir1, ir2},
+				}
+			},
+			expFeeds: map[string]string{
+				"test/system/s1/history-feed.atom": `
+<?xml version="1.0" encoding="UTF-8"?><feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Test - System 1 incident history</title>
+  <id>https://status.slok.dev/system/s1</id>
+  <updated>1912-06-23T01:02:03Z</updated>
+  <subtitle>Test status page (System 1)</subtitle>
+  <link href="https://status.slok.dev/system/s1" rel="alternate"></link>
+  <author>
+    <name>Test</name>
+  </author>
+  <entry>
+    <title>IR 1</title>
+    <updated>1912-06-23T06:22:03Z</updated>
+    <id>https://status.slok.dev/ir/ir1</id>
+    <content type="html">&#xA;&#xA;&lt;p&gt;&#xA;    &lt;small&gt;1912-06-23T06:22:03Z&lt;/small&gt;&#xA;    &lt;br /&gt;&#xA;    &lt;strong&gt;resolved&lt;/strong&gt;&#xA;     - &lt;p&gt;d12&lt;/p&gt;&#xA;&#xA;&lt;/p&gt;&#xA;&#xA;</content>
+    <link href="https://status.slok.dev/ir/ir1" rel="alternate" type="text/html"></link>
+  </entry>
+</feed>
+`,
+				"test/system/s2/history-feed.atom": `
+<?xml version="1.0" encoding="UTF-8"?><feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Test - System 2 incident history</title>
+  <id>https://status.slok.dev/system/s2</id>
+  <updated>1912-06-23T01:02:03Z</updated>
+  <subtitle>Test status page (System 2)</subtitle>
+  <link href="https://status.slok.dev/system/s2" rel="alternate"></link>
+  <author>
+    <name>Test</name>
+  </author>
+  <entry>
+    <title>IR 1</title>
+    <updated>1912-06-23T06:22:03Z</updated>
+    <id>https://status.slok.dev/ir/ir1</id>
+    <content type="html">&#xA;&#xA;&lt;p&gt;&#xA;    &lt;small&gt;1912-06-23T06:22:03Z&lt;/small&gt;&#xA;    &lt;br /&gt;&#xA;    &lt;strong&gt;resolved&lt;/strong&gt;&#xA;     - &lt;p&gt;d12&lt;/p&gt;&#xA;&#xA;&lt;/p&gt;&#xA;&#xA;</content>
+    <link href="https://status.slok.dev/ir/ir1" rel="alternate" type="text/html"></link>
+  </entry>
+</feed>
+`,
+				"test/system/s3/history-feed.atom": `
+<?xml version="1.0" encoding="UTF-8"?><feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Test - System 3 incident history</title>
+  <id>https://status.slok.dev/system/s3</id>
+  <updated>1912-06-23T01:02:03Z</updated>
+  <subtitle>Test status page (System 3)</subtitle>
+  <link href="https://status.slok.dev/system/s3" rel="alternate"></link>
+  <author>
+    <name>Test</name>
+  </author>
+</feed>
+`,
+			},
+		},
+
+		"Update feed mode should generate an entry per incident update.": {
+			historyItems: 3,
+			ui: func() model.UI {
+				return model.UI{
+					Settings: model.StatusPageSettings{
+						Name: "Test",
+						URL:  "https://status.slok.dev",
+						Feed: model.FeedSettings{Mode: model.FeedModeUpdate, DisableRSS: true, DisableJSON: true},
+					},
+					History: []*model.IncidentReport{
+						{ID: "ir2", Name: "IR 2", Start: t0.Add(300 * time.Minute), End: t0.Add(320 * time.Minute), Duration: 20 * time.Minute, Timeline: []model.IncidentReportEvent{
+							{TS: t0.Add(320 * time.Minute), Description: "d22", Kind: model.IncidentUpdateKindResolved},
+							{TS: t0.Add(300 * time.Minute), Description: "d21", Kind: model.IncidentUpdateKindInvestigating},
+						}},
+						{ID: "ir1", Name: "IR 1", Start: t0.Add(100 * time.Minute), End: t0.Add(310 * time.Minute), Duration: 210 * time.Minute, Timeline: []model.IncidentReportEvent{
+							{TS: t0.Add(310 * time.Minute), Description: "d12", Kind: model.IncidentUpdateKindResolved},
+							{TS: t0.Add(100 * time.Minute), Description: "d11", Kind: model.IncidentUpdateKindInvestigating},
+						}},
+					},
+				}
+			},
+			expNotExistent: []string{
+				"test/history-feed.rss",
+				"test/history-feed.json",
+			},
+			expFeeds: map[string]string{
+				"test/history-feed.atom": `
+<?xml version="1.0" encoding="UTF-8"?><feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Test - Incident history</title>
+  <id>https://status.slok.dev</id>
+  <updated>1912-06-23T01:02:03Z</updated>
+  <subtitle>Test status page</subtitle>
+  <link href="https://status.slok.dev" rel="alternate"></link>
+  <author>
+    <name>Test</name>
+  </author>
+  <entry>
+    <title>[Resolved] IR 2</title>
+    <updated>1912-06-23T06:22:03Z</updated>
+    <id>https://status.slok.dev/ir/ir2#update-1912-06-23T06:22:03Z</id>
+    <content type="html">&#xA;&#xA;&lt;p&gt;&#xA;    &lt;small&gt;1912-06-23T06:22:03Z&lt;/small&gt;&#xA;    &lt;br /&gt;&#xA;    &lt;strong&gt;resolved&lt;/strong&gt;&#xA;     - &lt;p&gt;d22&lt;/p&gt;&#xA;&#xA;&lt;/p&gt;&#xA;&#xA;</content>
+    <link href="https://status.slok.dev/ir/ir2" rel="alternate" type="text/html"></link>
+  </entry>
+  <entry>
+    <title>[Resolved] IR 1</title>
+    <updated>1912-06-23T06:12:03Z</updated>
+    <id>https://status.slok.dev/ir/ir1#update-1912-06-23T06:12:03Z</id>
+    <content type="html">&#xA;&#xA;&lt;p&gt;&#xA;    &lt;small&gt;1912-06-23T06:12:03Z&lt;/small&gt;&#xA;    &lt;br /&gt;&#xA;    &lt;strong&gt;resolved&lt;/strong&gt;&#xA;     - &lt;p&gt;d12&lt;/p&gt;&#xA;&#xA;&lt;/p&gt;&#xA;&#xA;</content>
+    <link href="https://status.slok.dev/ir/ir1" rel="alternate" type="text/html"></link>
+  </entry>
+  <entry>
+    <title>[Investigating] IR 2</title>
+    <updated>1912-06-23T06:02:03Z</updated>
+    <id>https://status.slok.dev/ir/ir2#update-1912-06-23T06:02:03Z</id>
+    <content type="html">&#xA;&#xA;&lt;p&gt;&#xA;    &lt;small&gt;1912-06-23T06:02:03Z&lt;/small&gt;&#xA;    &lt;br /&gt;&#xA;    &lt;strong&gt;investigating&lt;/strong&gt;&#xA;     - &lt;p&gt;d21&lt;/p&gt;&#xA;&#xA;&lt;/p&gt;&#xA;&#xA;</content>
+    <link href="https://status.slok.dev/ir/ir2" rel="alternate" type="text/html"></link>
+  </entry>
+</feed>
+`,
+			},
+		},
+
+		"Update feed mode should generate unique entries for the incident updates with the same TS.": {
+			ui: func() model.UI {
+				return model.UI{
+					Settings: model.StatusPageSettings{
+						Name: "Test",
+						URL:  "https://status.slok.dev",
+						Feed: model.FeedSettings{Mode: model.FeedModeUpdate, DisableRSS: true, DisableJSON: true},
+					},
+					History: []*model.IncidentReport{
+						{ID: "ir1", Name: "IR 1", Start: t0.Add(100 * time.Minute), Timeline: []model.IncidentReportEvent{
+							{TS: t0.Add(100 * time.Minute), Description: "d12"},
+							{TS: t0.Add(100 * time.Minute), Description: "d11", Kind: model.IncidentUpdateKindInvestigating},
+						}},
+					},
+				}
+			},
+			expFeeds: map[string]string{
+				"test/history-feed.atom": `
+<?xml version="1.0" encoding="UTF-8"?><feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Test - Incident history</title>
+  <id>https://status.slok.dev</id>
+  <updated>1912-06-23T01:02:03Z</updated>
+  <subtitle>Test status page</subtitle>
+  <link href="https://status.slok.dev" rel="alternate"></link>
+  <author>
+    <name>Test</name>
+  </author>
+  <entry>
+    <title>[Update] IR 1</title>
+    <updated>1912-06-23T02:42:03Z</updated>
+    <id>https://status.slok.dev/ir/ir1#update-1912-06-23T02:42:03Z-2</id>
+    <content type="html">&#xA;&#xA;&lt;p&gt;&#xA;    &lt;small&gt;1912-06-23T02:42:03Z&lt;/small&gt;&#xA;    &lt;br /&gt;&#xA;    &lt;strong&gt;&lt;/strong&gt;&#xA;     - &lt;p&gt;d12&lt;/p&gt;&#xA;&#xA;&lt;/p&gt;&#xA;&#xA;</content>
+    <link href="https://status.slok.dev/ir/ir1" rel="alternate" type="text/html"></link>
+  </entry>
+  <entry>
+    <title>[Investigating] IR 1</title>
+    <updated>1912-06-23T02:42:03Z</updated>
+    <id>https://status.slok.dev/ir/ir1#update-1912-06-23T02:42:03Z</id>
+    <content type="html">&#xA;&#xA;&lt;p&gt;&#xA;    &lt;small&gt;1912-06-23T02:42:03Z&lt;/small&gt;&#xA;    &lt;br /&gt;&#xA;    &lt;strong&gt;investigating&lt;/strong&gt;&#xA;     - &lt;p&gt;d11&lt;/p&gt;&#xA;&#xA;&lt;/p&gt;&#xA;&#xA;</content>
+    <link href="https://status.slok.dev/ir/ir1" rel="alternate" type="text/html"></link>
+  </entry>
+</feed>
+`,
+			},
+		},
+
+		"Published postmortems should be flagged on the incident entries.": {
+			ui: func() model.UI {
+				return model.UI{
+					Settings: model.StatusPageSettings{
+						Name: "Test",
+						URL:  "https://status.slok.dev",
+						Feed: model.FeedSettings{DisableRSS: true, DisableJSON: true},
+					},
+					History: []*model.IncidentReport{
+						{ID: "ir1", Name: "IR 1", Start: t0.Add(100 * time.Minute), End: t0.Add(310 * time.Minute), Duration: 210 * time.Minute,
+							Timeline: []model.IncidentReportEvent{
+								{TS: t0.Add(310 * time.Minute), Description: "d12", Kind: model.IncidentUpdateKindResolved},
+							},
+							Postmortem: &model.Postmortem{Content: "## Root cause", TS: t0.Add(24 * time.Hour)},
+						},
+					},
+				}
+			},
+			expFeeds: map[string]string{
+				"test/history-feed.atom": `
+<?xml version="1.0" encoding="UTF-8"?><feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Test - Incident history</title>
+  <id>https://status.slok.dev</id>
+  <updated>1912-06-23T01:02:03Z</updated>
+  <subtitle>Test status page</subtitle>
+  <link href="https://status.slok.dev" rel="alternate"></link>
+  <author>
+    <name>Test</name>
+  </author>
+  <entry>
+    <title>IR 1</title>
+    <updated>1912-06-24T01:02:03Z</updated>
+    <id>https://status.slok.dev/ir/ir1</id>
+    <content type="html">&lt;p&gt;&lt;strong&gt;&lt;a href=&#34;https://status.slok.dev/ir/ir1#postmortem&#34;&gt;Postmortem published&lt;/a&gt;&lt;/strong&gt;&lt;/p&gt;&#xA;&#xA;&lt;p&gt;&#xA;    &lt;small&gt;1912-06-23T06:12:03Z&lt;/small&gt;&#xA;    &lt;br /&gt;&#xA;    &lt;strong&gt;resolved&lt;/strong&gt;&#xA;     - &lt;p&gt;d12&lt;/p&gt;&#xA;&#xA;&lt;/p&gt;&#xA;&#xA;</content>
+    <link href="https://status.slok.dev/ir/ir1" rel="alternate" type="text/html"></link>
+  </entry>
+</feed>
+`,
+			},
+		},
+
+		"Update feed mode should generate an entry per published postmortem.": {
+			ui: func() model.UI {
+				return model.UI{
+					Settings: model.StatusPageSettings{
+						Name: "Test",
+						URL:  "https://status.slok.dev",
+						Feed: model.FeedSettings{Mode: model.FeedModeUpdate, DisableRSS: true, DisableJSON: true},
+					},
+					History: []*model.IncidentReport{
+						{ID: "ir1", Name: "IR 1", Start: t0.Add(100 * time.Minute), End: t0.Add(310 * time.Minute), Duration: 210 * time.Minute,
+							Timeline: []model.IncidentReportEvent{
+								{TS: t0.Add(310 * time.Minute), Description: "d12", Kind: model.IncidentUpdateKindResolved},
+							},
+							Postmortem: &model.Postmortem{Content: "## Root cause", TS: t0.Add(24 * time.Hour)},
+						},
+					},
+				}
+			},
+			expFeeds: map[string]string{
+				"test/history-feed.atom": `
+<?xml version="1.0" encoding="UTF-8"?><feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Test - Incident history</title>
+  <id>https://status.slok.dev</id>
+  <updated>1912-06-23T01:02:03Z</updated>
+  <subtitle>Test status page</subtitle>
+  <link href="https://status.slok.dev" rel="alternate"></link>
+  <author>
+    <name>Test</name>
+  </author>
+  <entry>
+    <title>[Postmortem published] IR 1</title>
+    <updated>1912-06-24T01:02:03Z</updated>
+    <id>https://status.slok.dev/ir/ir1#postmortem</id>
+    <content type="html">&lt;p&gt;&lt;strong&gt;&lt;a href=&#34;https://status.slok.dev/ir/ir1#postmortem&#34;&gt;Postmortem published&lt;/a&gt;&lt;/strong&gt;&lt;/p&gt;</content>
+    <link href="https://status.slok.dev/ir/ir1#postmortem" rel="alternate" type="text/html"></link>
+  </entry>
+  <entry>
+    <title>[Resolved] IR 1</title>
+    <updated>1912-06-23T06:12:03Z</updated>
+    <id>https://status.slok.dev/ir/ir1#update-1912-06-23T06:12:03Z</id>
+    <content type="html">&#xA;&#xA;&lt;p&gt;&#xA;    &lt;small&gt;1912-06-23T06:12:03Z&lt;/small&gt;&#xA;    &lt;br /&gt;&#xA;    &lt;strong&gt;resolved&lt;/strong&gt;&#xA;     - &lt;p&gt;d12&lt;/p&gt;&#xA;&#xA;&lt;/p&gt;&#xA;&#xA;</content>
+    <link href="https://status.slok.dev/ir/ir1" rel="alternate" type="text/html"></link>
+  </entry>
+</feed>
+`,
+			},
+		},
+
+		"Relative references on the incident updates should point to their hashed assets.": {
+			ui: func() model.UI {
+				return model.UI{
+					Settings: model.StatusPageSettings{
+						Name: "Test",
+						URL:  "https://status.slok.dev",
+						Feed: model.FeedSettings{DisableRSS: true, DisableJSON: true},
+					},
+					History: []*model.IncidentReport{
+						{ID: "ir1", Name: "IR 1", Start: t0.Add(100 * time.Minute), End: t0.Add(310 * time.Minute), Duration: 210 * time.Minute,
+							Timeline: []model.IncidentReportEvent{
+								{TS: t0.Add(310 * time.Minute), Description: "![graph](./graph.png)", Kind: model.IncidentUpdateKindResolved,
+									Assets: map[string]model.Asset{"./graph.png": {Path: "graph.png", Data: []byte("png")}}},
+							},
+						},
+					},
+				}
+			},
+			expFeeds: map[string]string{
+				"test/history-feed.atom": `
+<?xml version="1.0" encoding="UTF-8"?><feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Test - Incident history</title>
+  <id>https://status.slok.dev</id>
+  <updated>1912-06-23T01:02:03Z</updated>
+  <subtitle>Test status page</subtitle>
+  <link href="https://status.slok.dev" rel="alternate"></link>
+  <author>
+    <name>Test</name>
+  </author>
+  <entry>
+    <title>IR 1</title>
+    <updated>1912-06-23T06:12:03Z</updated>
+    <id>https://status.slok.dev/ir/ir1</id>
+    <content type="html">&#xA;&#xA;&lt;p&gt;&#xA;    &lt;small&gt;1912-06-23T06:12:03Z&lt;/small&gt;&#xA;    &lt;br /&gt;&#xA;    &lt;strong&gt;resolved&lt;/strong&gt;&#xA;     - &lt;p&gt;&lt;img src=&#34;https://status.slok.dev/assets/8f8cbb7dcf46e0bc/graph.png&#34; alt=&#34;graph&#34;&gt;&lt;/p&gt;&#xA;&#xA;&lt;/p&gt;&#xA;&#xA;</content>
+    <link href="https://status.slok.dev/ir/ir1" rel="alternate" type="text/html"></link>
+  </entry>
+</feed>
+`,
+			},
+		},
+
+		"Disabled feed formats should not be generated and the enabled ones should be generated.": {
+			ui: func() model.UI {
+				return model.UI{
+					Settings: model.StatusPageSettings{
+						Name: "Test",
+						URL:  "https://status.slok.dev",
+						Feed: model.FeedSettings{DisableAtom: true},
+					},
+					SystemDetails: []model.SystemDetails{
+						{System: model.System{ID: "s1", Name: "System 1"}},
+					},
+					History: []*model.IncidentReport{
+						{ID: "ir1", Name: "IR 1", SystemIDs: []string{"s1"}, Start: t0.Add(300 * time.Minute), End: t0.Add(320 * time.Minute), Duration: 20 * time.Minute, Timeline: []model.IncidentReportEvent{
+							{TS: t0.Add(320 * time.Minute), Description: "d12", Kind: model.IncidentUpdateKindResolved},
+						}},
+					},
+				}
+			},
+			expNotExistent: []string{
+				"test/history-feed.atom",
+				"test/system/s1/history-feed.atom",
+			},
+			expFeeds: map[string]string{
+				"test/history-feed.rss": `
+<?xml version="1.0" encoding="UTF-8"?><rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/">
+  <channel>
+    <title>Test - Incident history</title>
+    <link>https://status.slok.dev</link>
+    <description>Test status page</description>
+    <managingEditor> (Test)</managingEditor>
+    <pubDate>Sun, 23 Jun 1912 01:02:03 +0000</pubDate>
+    <lastBuildDate>Sun, 23 Jun 1912 01:02:03 +0000</lastBuildDate>
+    <item>
+      <title>IR 1</title>
+      <link>https://status.slok.dev/ir/ir1</link>
+      <description></description>
+      <content:encoded><![CDATA[
+
+<p>
+    <small>1912-06-23T06:22:03Z</small>
+    <br />
+    <strong>resolved</strong>
+     - <p>d12</p>
+
+</p>
+
+]]></content:encoded>
+      <guid>https://status.slok.dev/ir/ir1</guid>
+      <pubDate>Sun, 23 Jun 1912 06:02:03 +0000</pubDate>
+    </item>
+  </channel>
+</rss>
+`,
+				"test/history-feed.json": `
+{
+  "version": "https://jsonfeed.org/version/1.1",
+  "title": "Test - Incident history",
+  "home_page_url": "https://status.slok.dev",
+  "description": "Test status page",
+  "author": {
+    "name": "Test"
+  },
+  "authors": [
+    {
+      "name": "Test"
+    }
+  ],
+  "items": [
+    {
+      "id": "https://status.slok.dev/ir/ir1",
+      "url": "https://status.slok.dev/ir/ir1",
+      "title": "IR 1",
+      "content_html": "\n\n\u003cp\u003e\n    \u003csmall\u003e1912-06-23T06:22:03Z\u003c/small\u003e\n    \u003cbr /\u003e\n    \u003cstrong\u003eresolved\u003c/strong\u003e\n     - \u003cp\u003ed12\u003c/p\u003e\n\n\u003c/p\u003e\n\n",
+      "date_published": "1912-06-23T06:02:03Z",
+      "date_modified": "1912-06-23T06:22:03Z"
+    }
+  ]
+}
+`,
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -91,7 +490,8 @@ func TestRepositoryCreateHistoryFeed(t *testing.T) {
 			fsm := utilfs.NewTestFileManager()
 			repo, err := feed.NewFSRepository(feed.RepositoryConfig{
 				FileManager:         fsm,
-				AtomHistoryFilePath: "test/history-feed.atom",
+				OutPath:             "test",
+				HistoryItemsPerFeed: test.historyItems,
 				TimeNow:             func() time.Time { return t0 },
 			})
 			require.NoError(err)
@@ -103,6 +503,9 @@ func TestRepositoryCreateHistoryFeed(t *testing.T) {
 				for k, v := range test.expFeeds {
 					fsm.AssertEqual(t, k, v)
 				}
+				for _, k := range test.expNotExistent {
+					fsm.AssertNotExists(t, k)
+				}
 			}
 		})
 	}
@@ -0,0 +1,125 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ImpactOK is the impact used for the systems and days without incidents.
+const ImpactOK = "ok"
+
+// impactColors are the colors of the impacts, the same ones the themes use on their light styles,
+// all of them meet WCAG AA contrast with white.
+var impactColors = map[string]string{
+	ImpactOK:   "#1A7F37",
+	"none":     "#59636E",
+	"minor":    "#9A6700",
+	"major":    "#BC4C00",
+	"critical": "#CF222E",
+}
+
+// ImpactColor returns the hex color of an impact (e.g: `minor`, `ok`), unknown impacts will use the
+// no impact color.
+func ImpactColor(impact string) string {
+	c, ok := impactColors[impact]
+	if !ok {
+		return impactColors["none"]
+	}
+
+	return c
+}
+
+// ImpactLabel returns the translated label of an impact (e.g: `Major impact`).
+func (t *ThemeRenderer) ImpactLabel(impact string) string {
+	if _, ok := impactColors[impact]; !ok {
+		impact = "none"
+	}
+
+	return t.Translate("impact_label_" + impact)
+}
+
+type durationUnit struct {
+	d   time.Duration
+	key string
+}
+
+var durationUnits = []durationUnit{
+	{d: 24 * time.Hour, key: "day"},
+	{d: time.Hour, key: "hour"},
+	{d: time.Minute, key: "minute"},
+	{d: time.Second, key: "second"},
+}
+
+// HumanizeDuration returns a translated human friendly duration with its 2 most significant
+// units (e.g: `1 hour 27 minutes`).
+func (t *ThemeRenderer) HumanizeDuration(d time.Duration) string {
+	return t.humanizeDuration(d, 2)
+}
+
+func (t *ThemeRenderer) humanizeDuration(d time.Duration, maxUnits int) string {
+	if d < 0 {
+		d = -d
+	}
+	d = d.Round(time.Second)
+
+	parts := []string{}
+	for _, u := range durationUnits {
+		if len(parts) >= maxUnits {
+			break
+		}
+
+		n := int(d / u.d)
+		if n == 0 {
+			// Once we started, the gaps between units are not shown (e.g: `1 day 5 minutes`).
+			if len(parts) > 0 {
+				break
+			}
+			continue
+		}
+		d -= time.Duration(n) * u.d
+		parts = append(parts, t.pluralize(n, "duration_"+u.key))
+	}
+
+	if len(parts) == 0 {
+		return t.pluralize(0, "duration_second")
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// TimeAgo returns a translated time relative to the generation time (e.g: `3 hours ago`, `in 2 days`).
+func (t *ThemeRenderer) TimeAgo(ts time.Time) string {
+	d := t.timeNow().Sub(ts)
+	switch {
+	case d > -time.Minute && d < time.Minute:
+		return t.Translate("time_just_now")
+	case d < 0:
+		return t.Translate("time_in", t.humanizeDuration(d, 1))
+	default:
+		return t.Translate("time_ago", t.humanizeDuration(d, 1))
+	}
+}
+
+// pluralize uses the singular (`{key}`) or the plural (`{key}s`) message based on the number.
+func (t *ThemeRenderer) pluralize(n int, key string) string {
+	if n != 1 {
+		key += "s"
+	}
+
+	return t.Translate(key, n)
+}
+
+func (t *ThemeRenderer) funcs() map[string]any {
+	return map[string]any{
+		// Translates a message key, e.g: `{{ t "incident_resolved_in" .Duration }}`.
+		"t": t.Translate,
+		// Human friendly durations, e.g: `{{ humanizeDuration .Duration }}` -> `1 hour 27 minutes`.
+		"humanizeDuration": t.HumanizeDuration,
+		// Times relative to the generation time, e.g: `{{ timeAgo .TS }}` -> `3 hours ago`.
+		"timeAgo": t.TimeAgo,
+		// Impact labels and colors, e.g: `{{ impactLabel .Impact }}` -> `Major impact`.
+		"impactLabel": func(impact any) string { return t.ImpactLabel(fmt.Sprint(impact)) },
+		"impactColor": func(impact any) string { return ImpactColor(fmt.Sprint(impact)) },
+	}
+}
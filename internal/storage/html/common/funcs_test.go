@@ -0,0 +1,143 @@
+package common_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slok/stactus/internal/storage/html/common"
+)
+
+const testCatalogEN = `
+duration_day: "%d day"
+duration_days: "%d days"
+duration_hour: "%d hour"
+duration_hours: "%d hours"
+duration_minute: "%d minute"
+duration_minutes: "%d minutes"
+duration_second: "%d second"
+duration_seconds: "%d seconds"
+time_ago: "%s ago"
+time_in: "in %s"
+time_just_now: just now
+impact_label_ok: Operational
+impact_label_none: No impact
+impact_label_major: Major impact
+`
+
+const testCatalogES = `
+duration_hour: "%d hora"
+duration_hours: "%d horas"
+time_ago: "hace %s"
+`
+
+func TestThemeRendererFuncs(t *testing.T) {
+	t0 := time.Date(2024, 10, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		lang         string
+		fallbackLang string
+		tpl          string
+		data         any
+		expOut       string
+	}{
+		"Durations should be humanized with the 2 most significant units.": {
+			tpl:    `{{ humanizeDuration . }}`,
+			data:   time.Hour + 27*time.Minute + 34*time.Second + 1,
+			expOut: "1 hour 27 minutes",
+		},
+
+		"Durations should not show the units after a gap.": {
+			tpl:    `{{ humanizeDuration . }}`,
+			data:   24*time.Hour + 5*time.Minute,
+			expOut: "1 day",
+		},
+
+		"Durations without the less significant units should only show the significant ones.": {
+			tpl:    `{{ humanizeDuration . }}`,
+			data:   2 * time.Hour,
+			expOut: "2 hours",
+		},
+
+		"Zero durations should be humanized.": {
+			tpl:    `{{ humanizeDuration . }}`,
+			data:   time.Duration(0),
+			expOut: "0 seconds",
+		},
+
+		"Past times should be relative to the generation time.": {
+			tpl:    `{{ timeAgo . }}`,
+			data:   t0.Add(-26 * time.Hour),
+			expOut: "1 day ago",
+		},
+
+		"Future times should be relative to the generation time.": {
+			tpl:    `{{ timeAgo . }}`,
+			data:   t0.Add(3 * time.Hour),
+			expOut: "in 3 hours",
+		},
+
+		"Recent times should be rendered as now.": {
+			tpl:    `{{ timeAgo . }}`,
+			data:   t0.Add(-20 * time.Second),
+			expOut: "just now",
+		},
+
+		"Impacts should have labels and colors.": {
+			tpl:    `{{ impactLabel . }} {{ impactColor . }}`,
+			data:   "major",
+			expOut: "Major impact #BC4C00",
+		},
+
+		"Unknown impacts should use the no impact label and color.": {
+			tpl:    `{{ impactLabel . }} {{ impactColor . }}`,
+			data:   "",
+			expOut: "No impact #59636E",
+		},
+
+		"The missing messages should fall back to the fallback language and then to the default language.": {
+			lang:         "pt",
+			fallbackLang: "es",
+			tpl:          `{{ timeAgo . }}, {{ humanizeDuration 90000000000 }}`,
+			data:         t0.Add(-2 * time.Hour),
+			expOut:       "hace 2 horas, 1 minute 30 seconds",
+		},
+
+		"The functions should be translated and fall back to the default language.": {
+			lang:   "es",
+			tpl:    `{{ timeAgo . }}, {{ humanizeDuration 90000000000 }}`,
+			data:   t0.Add(-2 * time.Hour),
+			expOut: "hace 2 horas, 1 minute 30 seconds",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			fs := fstest.MapFS{
+				"templates/test.tpl": {Data: []byte(`{{ define "test" }}` + test.tpl + `{{ end }}`)},
+				"static/main.css":    {Data: []byte("")},
+				"i18n/en.yaml":       {Data: []byte(testCatalogEN)},
+				"i18n/es.yaml":       {Data: []byte(testCatalogES)},
+			}
+			r, err := common.NewThemeRenderer(fs, fs)
+			require.NoError(err)
+			r, err = r.WithTimeNow(func() time.Time { return t0 })
+			require.NoError(err)
+			if test.lang != "" {
+				r, err = r.Localized(test.lang, test.fallbackLang)
+				require.NoError(err)
+			}
+
+			gotOut, err := r.Render(context.TODO(), "test", test.data)
+			require.NoError(err)
+			assert.Equal(test.expOut, gotOut)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ThemeDirI18n = "i18n"
+
+	// DefaultLanguage is the language of the themes messages, used when a message is missing on the
+	// requested and fallback languages.
+	DefaultLanguage = "en"
+
+	// MessageKeyLanguageName is the message key of the language name (in its own language), used
+	// on language switchers.
+	MessageKeyLanguageName = "language_name"
+)
+
+// Messages are translation catalogs by language (e.g: `es`, `pt-BR`), each catalog is
+// a map of message keys and their translated messages.
+type Messages map[string]map[string]string
+
+// LoadMessages loads the message catalogs of a directory, each catalog is a flat YAML
+// file named by its language (e.g: `es.yaml`).
+func LoadMessages(fsys fs.FS) (Messages, error) {
+	msgs := Messages{}
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		extension := strings.ToLower(filepath.Ext(path))
+		if d.IsDir() || (extension != ".yaml" && extension != ".yml") {
+			return nil
+		}
+
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+
+		catalog := map[string]string{}
+		err = yaml.Unmarshal(data, &catalog)
+		if err != nil {
+			return fmt.Errorf("could not load %q catalog: %w", path, err)
+		}
+
+		lang := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		msgs.merge(Messages{lang: catalog})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return msgs, nil
+}
+
+// merge sets the messages of other on top of the current ones.
+func (m Messages) merge(other Messages) {
+	for lang, catalog := range other {
+		if m[lang] == nil {
+			m[lang] = map[string]string{}
+		}
+		for k, v := range catalog {
+			m[lang][k] = v
+		}
+	}
+}
+
+// Translate returns the message of the key in the required language, missing messages fall
+// back to the fallback language (e.g: the site default language), the default language and
+// to the key itself. If args are passed, the message will be used as a format.
+func (m Messages) Translate(lang, fallbackLang, key string, args ...any) string {
+	msg, ok := m[lang][key]
+	if !ok && fallbackLang != "" {
+		msg, ok = m[fallbackLang][key]
+	}
+	if !ok {
+		msg, ok = m[DefaultLanguage][key]
+	}
+	if !ok {
+		msg = key
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+
+	return msg
+}
+
+func loadThemeMessages(templatesFS fs.FS) (Messages, error) {
+	_, err := fs.Stat(templatesFS, ThemeDirI18n)
+	if err != nil {
+		// Translations are optional.
+		if errors.Is(err, fs.ErrNotExist) {
+			return Messages{}, nil
+		}
+		return nil, fmt.Errorf("could not check %q dir: %w", ThemeDirI18n, err)
+	}
+
+	i18nfs, err := fs.Sub(templatesFS, ThemeDirI18n)
+	if err != nil {
+		return nil, fmt.Errorf("could not get %q sub dir: %w", ThemeDirI18n, err)
+	}
+
+	return LoadMessages(i18nfs)
+}
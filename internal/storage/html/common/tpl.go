@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/Masterminds/sprig/v3"
 	"github.com/slok/stactus/internal/conventions"
@@ -24,10 +25,16 @@ const (
 type ThemeRenderer struct {
 	tpls        *template.Template
 	staticFiles map[string]string
+	messages    Messages
+	lang        string
+	// fallbackLang is the language used for the messages missing on the renderer language.
+	fallbackLang string
+	timeNow      func() time.Time
 }
 
 // NewOSFSThemeRenderer returns a theme rendered based on real OS FS. The directory
-// must have `static“ and `templates“ directories.
+// must have `static“ and `templates“ directories, optionally an `i18n` directory
+// with the message catalogs.
 func NewOSFSThemeRenderer(dir string) (*ThemeRenderer, error) {
 	d := os.DirFS(dir)
 	return NewThemeRenderer(d, d)
@@ -71,8 +78,14 @@ func NewThemeRenderer(staticFS fs.FS, templatesFS fs.FS) (*ThemeRenderer, error)
 		return nil, fmt.Errorf("could not discover template paths: %w", err)
 	}
 
+	messages, err := loadThemeMessages(templatesFS)
+	if err != nil {
+		return nil, fmt.Errorf("could not load translations: %w", err)
+	}
+
 	// Parse all templates.
-	templates, err := template.New("base").Funcs(sprig.FuncMap()).ParseFS(templatesFS, templatePaths...)
+	r := &ThemeRenderer{messages: messages, lang: DefaultLanguage, timeNow: time.Now}
+	templates, err := template.New("base").Funcs(sprig.FuncMap()).Funcs(r.funcs()).ParseFS(templatesFS, templatePaths...)
 	if err != nil {
 		return nil, fmt.Errorf("could not parse templates: %w", err)
 	}
@@ -111,10 +124,81 @@ func NewThemeRenderer(staticFS fs.FS, templatesFS fs.FS) (*ThemeRenderer, error)
 		return nil, fmt.Errorf("could not load static files: %w", err)
 	}
 
-	return &ThemeRenderer{
-		tpls:        templates,
-		staticFiles: staticFiles,
-	}, nil
+	r.tpls = templates
+	r.staticFiles = staticFiles
+
+	return r, nil
+}
+
+// WithMessages returns a new renderer with the messages set on top of the theme ones, this way
+// the translations can be overridden or extended with new languages.
+func (t *ThemeRenderer) WithMessages(msgs Messages) (*ThemeRenderer, error) {
+	merged := Messages{}
+	merged.merge(t.messages)
+	merged.merge(msgs)
+
+	return t.clone(func(r *ThemeRenderer) { r.messages = merged })
+}
+
+// WithFallbackMessages returns a new renderer with the messages set below the theme ones, this
+// way custom themes only need to translate their own messages.
+func (t *ThemeRenderer) WithFallbackMessages(msgs Messages) (*ThemeRenderer, error) {
+	merged := Messages{}
+	merged.merge(msgs)
+	merged.merge(t.messages)
+
+	return t.clone(func(r *ThemeRenderer) { r.messages = merged })
+}
+
+// Localized returns a new renderer that will render the templates in the required language, the
+// missing messages will use the fallback language (e.g: the site default language), optional.
+func (t *ThemeRenderer) Localized(lang, fallbackLang string) (*ThemeRenderer, error) {
+	return t.clone(func(r *ThemeRenderer) {
+		r.lang = lang
+		r.fallbackLang = fallbackLang
+	})
+}
+
+// WithTimeNow returns a new renderer that will use the time function as the generation time, used
+// to render times relative to the generation (e.g: `3 hours ago`).
+func (t *ThemeRenderer) WithTimeNow(timeNow func() time.Time) (*ThemeRenderer, error) {
+	return t.clone(func(r *ThemeRenderer) { r.timeNow = timeNow })
+}
+
+// clone returns a copy of the renderer with the modifications, the templates functions are
+// bound to the new renderer.
+func (t *ThemeRenderer) clone(modify func(r *ThemeRenderer)) (*ThemeRenderer, error) {
+	r := &ThemeRenderer{
+		staticFiles:  t.staticFiles,
+		messages:     t.messages,
+		lang:         t.lang,
+		fallbackLang: t.fallbackLang,
+		timeNow:      t.timeNow,
+	}
+	modify(r)
+
+	tpls, err := t.tpls.Clone()
+	if err != nil {
+		return nil, fmt.Errorf("could not clone templates: %w", err)
+	}
+	r.tpls = tpls.Funcs(r.funcs())
+
+	return r, nil
+}
+
+// Translate returns the message of the key in the renderer language.
+func (t *ThemeRenderer) Translate(key string, args ...any) string {
+	return t.messages.Translate(t.lang, t.fallbackLang, key, args...)
+}
+
+// LanguageName returns the name of the language in its own language (e.g: `Español`).
+func (t *ThemeRenderer) LanguageName(lang string) string {
+	name := t.messages[lang][MessageKeyLanguageName]
+	if name == "" {
+		return lang
+	}
+
+	return name
 }
 
 // Render will render theme templates.
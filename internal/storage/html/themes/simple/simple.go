@@ -3,8 +3,13 @@ package simple
 import (
 	"context"
 	"embed"
+	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"html/template"
+	"io/fs"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,7 +24,7 @@ import (
 var (
 	//go:embed all:static
 	staticFs embed.FS
-	//go:embed all:templates
+	//go:embed all:templates all:i18n
 	templatesFs embed.FS
 )
 
@@ -27,16 +32,26 @@ type Generator struct {
 	fileManager utilfs.FileManager
 	renderer    common.ThemeRenderer
 	outPath     string
+	timeNow     func() time.Time
 
-	historyIRPerPage int
+	historyIRPerPage  int
+	systemUptimeDays  int
+	searchIRsPerShard int
 }
 
 type GeneratorConfig struct {
-	FileManager      utilfs.FileManager
-	OutPath          string
-	Logger           log.Logger
-	ThemeRenderer    *common.ThemeRenderer
+	FileManager   utilfs.FileManager
+	OutPath       string
+	Logger        log.Logger
+	ThemeRenderer *common.ThemeRenderer
+	// Messages override or add translations to the theme messages.
+	Messages         common.Messages
 	HistoryIRPerPage int
+	SystemUptimeDays int
+	// SearchIRsPerShard is the number of incidents on each search index shard, this way
+	// the browser only downloads the required parts of the index on big histories.
+	SearchIRsPerShard int
+	TimeNow           func() time.Time
 }
 
 func (c *GeneratorConfig) defaults() error {
@@ -59,6 +74,28 @@ func (c *GeneratorConfig) defaults() error {
 			return fmt.Errorf("could not create theme renderer: %w", err)
 		}
 		c.ThemeRenderer = rend
+	} else {
+		// Custom themes based on this one, only need to translate their own messages.
+		i18nfs, err := fs.Sub(templatesFs, common.ThemeDirI18n)
+		if err != nil {
+			return fmt.Errorf("could not get theme translations: %w", err)
+		}
+		msgs, err := common.LoadMessages(i18nfs)
+		if err != nil {
+			return fmt.Errorf("could not load theme translations: %w", err)
+		}
+		c.ThemeRenderer, err = c.ThemeRenderer.WithFallbackMessages(msgs)
+		if err != nil {
+			return fmt.Errorf("could not set theme translations: %w", err)
+		}
+	}
+
+	if len(c.Messages) > 0 {
+		rend, err := c.ThemeRenderer.WithMessages(c.Messages)
+		if err != nil {
+			return fmt.Errorf("could not set custom translations: %w", err)
+		}
+		c.ThemeRenderer = rend
 	}
 
 	if c.Logger == nil {
@@ -69,6 +106,18 @@ func (c *GeneratorConfig) defaults() error {
 		c.HistoryIRPerPage = 10
 	}
 
+	if c.SystemUptimeDays == 0 {
+		c.SystemUptimeDays = 90
+	}
+
+	if c.SearchIRsPerShard == 0 {
+		c.SearchIRsPerShard = 250
+	}
+
+	if c.TimeNow == nil {
+		c.TimeNow = func() time.Time { return time.Now().UTC() }
+	}
+
 	return nil
 }
 
@@ -79,11 +128,21 @@ func NewGenerator(config GeneratorConfig) (*Generator, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
+
+	// Relative times are based on the generation time.
+	renderer, err := config.ThemeRenderer.WithTimeNow(config.TimeNow)
+	if err != nil {
+		return nil, fmt.Errorf("could not set renderer time: %w", err)
+	}
+
 	g := &Generator{
-		fileManager:      config.FileManager,
-		renderer:         *config.ThemeRenderer,
-		outPath:          config.OutPath,
-		historyIRPerPage: config.HistoryIRPerPage,
+		fileManager:       config.FileManager,
+		renderer:          *renderer,
+		outPath:           config.OutPath,
+		timeNow:           config.TimeNow,
+		historyIRPerPage:  config.HistoryIRPerPage,
+		systemUptimeDays:  config.SystemUptimeDays,
+		searchIRsPerShard: config.SearchIRsPerShard,
 	}
 
 	return g, nil
@@ -94,34 +153,151 @@ func (g Generator) CreateUI(ctx context.Context, ui model.UI) error {
 	siteURL := strings.TrimSpace(ui.Settings.URL)
 	siteURL = strings.TrimSuffix(siteURL, "/")
 
+	defaultLang := ui.Settings.DefaultLanguage()
+	langs := ui.Settings.Languages
+	if len(langs) == 0 {
+		langs = []string{defaultLang}
+	}
+
+	markdown, err := newMarkdownRenderer(ui.Settings, siteURL)
+	if err != nil {
+		return fmt.Errorf("could not create markdown renderer: %w", err)
+	}
+
+	// Static files are shared by all the languages.
+	err = g.genStatic(ctx)
+	if err != nil {
+		return fmt.Errorf("could not generate static files: %w", err)
+	}
+
+	// Markdown assets are shared by all the languages too.
+	err = g.genAssets(ctx, ui)
+	if err != nil {
+		return fmt.Errorf("could not generate assets: %w", err)
+	}
+
+	// A site per language, the default one on the root and the rest under their language prefix.
+	languages := []languageTplData{}
+	for _, lang := range langs {
+		languages = append(languages, languageTplData{
+			Code:    lang,
+			Name:    g.renderer.LanguageName(lang),
+			SiteURL: conventions.LocalizedSiteURL(siteURL, lang, defaultLang),
+		})
+	}
+
+	for _, lang := range langs {
+		renderer, err := g.renderer.Localized(lang, defaultLang)
+		if err != nil {
+			return fmt.Errorf("could not localize %q theme: %w", lang, err)
+		}
+
+		lg := g
+		lg.renderer = *renderer
+		lg.outPath = conventions.LocalizedSiteFilePath(g.outPath, lang, defaultLang) + "/"
+
+		err = lg.genSite(ctx, ui.Localized(lang), siteURL, lang, languages, markdown)
+		if err != nil {
+			return fmt.Errorf("could not generate %q site: %w", lang, err)
+		}
+	}
+
+	err = g.genSitemap(ctx, ui, siteURL, languages)
+	if err != nil {
+		return fmt.Errorf("could not generate sitemap: %w", err)
+	}
+
+	return nil
+}
+
+// genSite will generate all the pages of the site in the generator language.
+func (g Generator) genSite(ctx context.Context, ui model.UI, siteURL, lang string, languages []languageTplData, markdown *utilhtml.MarkdownRenderer) error {
 	tplCommonData := tplCommonData{
 		BrandTitle:            ui.Settings.Name,
 		URLPrefix:             siteURL,
+		SiteURL:               siteURL,
 		PrometheusMetricsPath: conventions.PrometheusMetricsPathName,
-		AtomHistoryFeedPath:   conventions.IRHistoryAtomFeedPathName,
+		NoIndex:               ui.Settings.SEO.NoIndex,
+		Lang:                  lang,
+		StatusImpact:          common.ImpactOK,
+	}
+	if !ui.Status.Operational {
+		tplCommonData.StatusImpact = string(ui.Status.Impact)
+	}
+	if theme := ui.Settings.Theme.Simple; theme != nil {
+		if theme.ColorScheme != model.ColorSchemeAuto {
+			tplCommonData.ColorScheme = string(theme.ColorScheme)
+		}
+		tplCommonData.HighContrast = theme.HighContrast
+	}
+	for _, l := range languages {
+		l.Current = l.Code == lang
+		if l.Current {
+			tplCommonData.URLPrefix = l.SiteURL
+			tplCommonData.LanguageName = l.Name
+		}
+		// Only show the languages if there is something to choose.
+		if len(languages) > 1 {
+			tplCommonData.Languages = append(tplCommonData.Languages, l)
+		}
 	}
-	tplCommonData.HistoryURL = conventions.IRHistoryURL(tplCommonData.URLPrefix, 0)
 
-	err := g.genStatic(ctx)
-	if err != nil {
-		return fmt.Errorf("could not generate static files: %w", err)
+	tplCommonData.HistoryURL = conventions.IRHistoryURL(tplCommonData.URLPrefix, 0)
+	tplCommonData.SearchURL = conventions.SearchURL(tplCommonData.URLPrefix)
+	if !ui.Settings.Feed.DisableAtom {
+		tplCommonData.AtomHistoryFeedPath = conventions.IRHistoryAtomFeedPathName
+	}
+	if !ui.Settings.Feed.DisableRSS {
+		tplCommonData.RSSHistoryFeedPath = conventions.IRHistoryRSSFeedPathName
+	}
+	if !ui.Settings.Feed.DisableJSON {
+		tplCommonData.JSONHistoryFeedPath = conventions.IRHistoryJSONFeedPathName
+	}
+	for _, s := range ui.SystemDetails {
+		tplCommonData.SystemHistoryFeeds = append(tplCommonData.SystemHistoryFeeds, newSystemFeedTplData(tplCommonData, s.System))
+	}
+	for _, p := range ui.Pages {
+		tplCommonData.NavPages = append(tplCommonData.NavPages, navPageTplData{
+			Title: p.Title,
+			URL:   conventions.PageURL(tplCommonData.URLPrefix, p.Slug),
+		})
 	}
 
-	err = g.genDashboard(ctx, ui, tplCommonData)
+	err := g.genDashboard(ctx, ui, tplCommonData, markdown)
 	if err != nil {
 		return fmt.Errorf("could not generate dashboard: %w", err)
 	}
 
-	err = g.genHistory(ctx, ui, tplCommonData)
+	err = g.genHistory(ctx, ui, tplCommonData, markdown)
 	if err != nil {
 		return fmt.Errorf("could not generate history: %w", err)
 	}
 
-	err = g.genIRs(ctx, ui, tplCommonData)
+	err = g.genHistoryArchive(ctx, ui, tplCommonData, markdown)
+	if err != nil {
+		return fmt.Errorf("could not generate history archive: %w", err)
+	}
+
+	err = g.genSearch(ctx, ui, tplCommonData)
+	if err != nil {
+		return fmt.Errorf("could not generate search: %w", err)
+	}
+
+	err = g.genIRs(ctx, ui, tplCommonData, markdown)
 	if err != nil {
 		return fmt.Errorf("could not generate IRs details: %w", err)
 	}
 
+	err = g.genSystems(ctx, ui, tplCommonData)
+	if err != nil {
+		return fmt.Errorf("could not generate systems details: %w", err)
+	}
+
+	err = g.genPages(ctx, ui, tplCommonData, markdown)
+	if err != nil {
+		return fmt.Errorf("could not generate custom pages: %w", err)
+	}
+
 	return nil
 }
 
@@ -142,11 +318,64 @@ func (g Generator) genStatic(ctx context.Context) error {
 	return nil
 }
 
+// genAssets will generate the files referenced by the incidents Markdown contents (e.g: images).
+func (g Generator) genAssets(ctx context.Context, ui model.UI) error {
+	writeAssets := func(assets map[string]model.Asset) error {
+		for _, a := range assets {
+			err := g.fileManager.WriteFile(ctx, conventions.AssetFilePath(g.outPath, a.HashedPath()), a.Data)
+			if err != nil {
+				return fmt.Errorf("could not write %q asset: %w", a.Path, err)
+			}
+		}
+		return nil
+	}
+
+	for _, ir := range ui.History {
+		for _, ev := range ir.Timeline {
+			err := writeAssets(ev.Assets)
+			if err != nil {
+				return err
+			}
+			err = writeAssets(ev.PrivateNoteAssets)
+			if err != nil {
+				return err
+			}
+		}
+
+		if ir.Postmortem != nil {
+			err := writeAssets(ir.Postmortem.Assets)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// newMarkdownRenderer returns the renderer of the Markdown contents based on the status page settings.
+func newMarkdownRenderer(settings model.StatusPageSettings, siteURL string) (*utilhtml.MarkdownRenderer, error) {
+	return utilhtml.NewMarkdownRenderer(utilhtml.MarkdownRendererConfig{
+		DisableTables:        settings.Markdown.DisableTables,
+		DisableStrikethrough: settings.Markdown.DisableStrikethrough,
+		DisableAutolinks:     settings.Markdown.DisableAutolinks,
+		DisableTaskLists:     settings.Markdown.DisableTaskLists,
+		DisableFootnotes:     settings.Markdown.DisableFootnotes,
+		RawHTML:              settings.Markdown.RawHTML,
+		HeadingAnchors:       settings.Markdown.HeadingAnchors,
+		CodeHighlightStyle:   settings.Markdown.CodeHighlightStyle,
+		SiteURL:              siteURL,
+		ExternalLinkRel:      settings.Markdown.ExternalLinkRel,
+		ExternalLinkTarget:   settings.Markdown.ExternalLinkTarget,
+	})
+}
+
 // genDashboard will generate the dashboard related files.
-func (g Generator) genDashboard(ctx context.Context, ui model.UI, tplCommon tplCommonData) error {
+func (g Generator) genDashboard(ctx context.Context, ui model.UI, tplCommon tplCommonData, markdown *utilhtml.MarkdownRenderer) error {
 	type System struct {
 		Name        string
 		Description string
+		URL         string
 		OK          bool
 		Impact      string
 	}
@@ -167,12 +396,15 @@ func (g Generator) genDashboard(ctx context.Context, ui model.UI, tplCommon tplC
 	}
 
 	data := tplData{
-		tplCommonData: tplCommon,
-		AllOK:         len(ui.OpenedIRs) == 0,
+		tplCommonData: tplCommon.withMeta(pageMetaTplData{
+			Description:  g.siteDescription(ui),
+			CanonicalURL: tplCommon.URLPrefix + "/",
+		}),
+		AllOK: ui.Status.Operational,
 	}
 
 	for _, ir := range ui.OpenedIRs {
-		latestUpdate, err := utilhtml.RenderMarkdownToHTML(ir.Timeline[0].Description)
+		latestUpdate, err := markdown.RenderToHTML(ir.Timeline[0].Description, utilhtml.AssetURLResolver(tplCommon.SiteURL, ir.Timeline[0].Assets))
 		if err != nil {
 			return fmt.Errorf("could not render markdown: %w", err)
 		}
@@ -187,17 +419,12 @@ func (g Generator) genDashboard(ctx context.Context, ui model.UI, tplCommon tplC
 	}
 
 	for _, s := range ui.SystemDetails {
-		ok := true
-		impact := model.IncidentImpactNone
-		if s.LatestIR != nil && s.LatestIR.End.IsZero() {
-			ok = false
-			impact = s.LatestIR.Impact
-		}
 		data.Systems = append(data.Systems, System{
 			Name:        s.System.Name,
 			Description: s.System.Description,
-			OK:          ok,
-			Impact:      string(impact),
+			URL:         conventions.SystemDetailURL(tplCommon.URLPrefix, s.System.ID),
+			OK:          s.Status.Operational,
+			Impact:      string(s.Status.Impact),
 		})
 	}
 
@@ -216,32 +443,18 @@ func (g Generator) genDashboard(ctx context.Context, ui model.UI, tplCommon tplC
 }
 
 // genHistory will generate the history files.
-func (g Generator) genHistory(ctx context.Context, ui model.UI, tplCommon tplCommonData) error {
-	type incidentTplData struct {
-		Title        string
-		URL          string
-		LatestUpdate template.HTML
-		StartTS      time.Time
-		EndTS        time.Time
-		Impact       string
-	}
-
+func (g Generator) genHistory(ctx context.Context, ui model.UI, tplCommon tplCommonData, markdown *utilhtml.MarkdownRenderer) error {
 	type tplData struct {
 		tplCommonData
 		NextURL     string
 		PreviousURL string
-		Incidents   []incidentTplData
+		Incidents   []historyIncidentTplData
+		Archive     []archiveYearTplData
 	}
 
-	// Split incidents in pages.
-	pageIncidents := [][]*model.IncidentReport{}
-	for i := 0; i < len(ui.History); i += g.historyIRPerPage {
-		end := i + g.historyIRPerPage
-		if end > len(ui.History) {
-			end = len(ui.History)
-		}
-		pageIncidents = append(pageIncidents, ui.History[i:end])
-	}
+	archive := g.newArchiveTplData(tplCommon, groupIRsByMonth(ui.History))
+
+	pageIncidents := splitIRPages(ui.History, g.historyIRPerPage)
 
 	// Render a history per page.
 	for i, page := range pageIncidents {
@@ -256,32 +469,21 @@ func (g Generator) genHistory(ctx context.Context, ui model.UI, tplCommon tplCom
 			previousURL = ""
 		}
 
-		incidents := []incidentTplData{}
-		for _, ir := range page {
-			var latestUpdate template.HTML
-			var err error
-			if len(ir.Timeline) > 0 {
-				latestUpdate, err = utilhtml.RenderMarkdownToHTML(ir.Timeline[0].Description)
-				if err != nil {
-					return fmt.Errorf("could not render markdown: %w", err)
-				}
-			}
-
-			incidents = append(incidents, incidentTplData{
-				Title:        ir.Name,
-				URL:          conventions.IRDetailURL(tplCommon.URLPrefix, ir.ID),
-				LatestUpdate: latestUpdate,
-				StartTS:      ir.Start,
-				EndTS:        ir.End,
-				Impact:       string(ir.Impact),
-			})
+		incidents, err := newHistoryIncidentsTplData(tplCommon, page, markdown)
+		if err != nil {
+			return err
 		}
 
 		data := tplData{
-			tplCommonData: tplCommon,
-			NextURL:       nextURL,
-			PreviousURL:   previousURL,
-			Incidents:     incidents,
+			tplCommonData: tplCommon.withMeta(pageMetaTplData{
+				Title:        g.renderer.Translate("meta_history_title"),
+				Description:  g.renderer.Translate("meta_history_description", ui.Settings.Name),
+				CanonicalURL: conventions.IRHistoryURL(tplCommon.URLPrefix, i),
+			}),
+			NextURL:     nextURL,
+			PreviousURL: previousURL,
+			Incidents:   incidents,
+			Archive:     archive,
 		}
 
 		// Render history first page.
@@ -299,23 +501,369 @@ func (g Generator) genHistory(ctx context.Context, ui model.UI, tplCommon tplCom
 	return nil
 }
 
+// genHistoryArchive will generate the history archive files, a page per month with incidents,
+// with a calendar of the month and the incidents that started on that month.
+func (g Generator) genHistoryArchive(ctx context.Context, ui model.UI, tplCommon tplCommonData, markdown *utilhtml.MarkdownRenderer) error {
+	type calendarDayTplData struct {
+		Day    int // 0 for the days outside of the month (calendar padding).
+		TS     time.Time
+		IRs    int
+		Impact string
+	}
+
+	type tplData struct {
+		tplCommonData
+		Title       string
+		Weeks       [][]calendarDayTplData
+		NextURL     string
+		PreviousURL string
+		Incidents   []historyIncidentTplData
+	}
+
+	now := g.timeNow()
+	months := groupIRsByMonth(ui.History)
+	for i, m := range months {
+		// Months are sorted by the latest first.
+		nextURL, previousURL := "", ""
+		if i > 0 {
+			nextURL = conventions.IRHistoryArchiveURL(tplCommon.URLPrefix, months[i-1].Year, months[i-1].Month)
+		}
+		if i < len(months)-1 {
+			previousURL = conventions.IRHistoryArchiveURL(tplCommon.URLPrefix, months[i+1].Year, months[i+1].Month)
+		}
+
+		// Calendar weeks starting on Monday, the days are based on all the incidents that were active
+		// on that day, not only the ones started on the month.
+		monthStart := time.Date(m.Year, m.Month, 1, 0, 0, 0, 0, time.UTC)
+		monthEnd := monthStart.AddDate(0, 1, 0)
+		weeks := [][]calendarDayTplData{}
+		week := make([]calendarDayTplData, (int(monthStart.Weekday())+6)%7)
+		for dayStart := monthStart; dayStart.Before(monthEnd); dayStart = dayStart.AddDate(0, 0, 1) {
+			day := calendarDayTplData{Day: dayStart.Day(), TS: dayStart, Impact: "ok"}
+			worst := -1
+			for _, ir := range ui.History {
+				if !irOverlaps(ir, dayStart, dayStart.AddDate(0, 0, 1), now) {
+					continue
+				}
+				day.IRs++
+				if ir.Impact.Level() > worst {
+					worst = ir.Impact.Level()
+					day.Impact = string(ir.Impact)
+				}
+			}
+
+			week = append(week, day)
+			if len(week) == 7 {
+				weeks = append(weeks, week)
+				week = []calendarDayTplData{}
+			}
+		}
+		if len(week) > 0 {
+			week = append(week, make([]calendarDayTplData, 7-len(week))...)
+			weeks = append(weeks, week)
+		}
+
+		incidents, err := newHistoryIncidentsTplData(tplCommon, m.IRs, markdown)
+		if err != nil {
+			return err
+		}
+
+		title := fmt.Sprintf("%s %d", g.renderer.Translate(fmt.Sprintf("month_%d", m.Month)), m.Year)
+		data := tplData{
+			tplCommonData: tplCommon.withMeta(pageMetaTplData{
+				Title:        g.renderer.Translate("meta_archive_title", title),
+				Description:  g.renderer.Translate("meta_archive_description", len(m.IRs), ui.Settings.Name, title),
+				CanonicalURL: conventions.IRHistoryArchiveURL(tplCommon.URLPrefix, m.Year, m.Month),
+			}),
+			Title:       title,
+			Weeks:       weeks,
+			NextURL:     nextURL,
+			PreviousURL: previousURL,
+			Incidents:   incidents,
+		}
+
+		archive, err := g.renderer.Render(ctx, "page_history_archive", data)
+		if err != nil {
+			return fmt.Errorf("could not render %d/%02d archive: %w", m.Year, m.Month, err)
+		}
+
+		err = g.fileManager.WriteFile(ctx, conventions.IRHistoryArchiveFilePath(g.outPath, m.Year, m.Month), []byte(archive))
+		if err != nil {
+			return fmt.Errorf("could not write %d/%02d archive: %w", m.Year, m.Month, err)
+		}
+	}
+
+	return nil
+}
+
+type historyIncidentTplData struct {
+	Title        string
+	URL          string
+	LatestUpdate template.HTML
+	StartTS      time.Time
+	EndTS        time.Time
+	Impact       string
+	Draft        bool
+	Postmortem   bool
+}
+
+func newHistoryIncidentsTplData(tplCommon tplCommonData, irs []*model.IncidentReport, markdown *utilhtml.MarkdownRenderer) ([]historyIncidentTplData, error) {
+	incidents := []historyIncidentTplData{}
+	for _, ir := range irs {
+		var latestUpdate template.HTML
+		var err error
+		if len(ir.Timeline) > 0 {
+			latestUpdate, err = markdown.RenderToHTML(ir.Timeline[0].Description, utilhtml.AssetURLResolver(tplCommon.SiteURL, ir.Timeline[0].Assets))
+			if err != nil {
+				return nil, fmt.Errorf("could not render markdown: %w", err)
+			}
+		}
+
+		incidents = append(incidents, historyIncidentTplData{
+			Title:        ir.Name,
+			URL:          conventions.IRDetailURL(tplCommon.URLPrefix, ir.ID),
+			LatestUpdate: latestUpdate,
+			StartTS:      ir.Start,
+			EndTS:        ir.End,
+			Impact:       string(ir.Impact),
+			Draft:        ir.Visibility == model.IncidentVisibilityDraft,
+			Postmortem:   ir.Postmortem != nil,
+		})
+	}
+
+	return incidents, nil
+}
+
+// archiveMonth are the incidents that started on a specific month.
+type archiveMonth struct {
+	Year  int
+	Month time.Month
+	IRs   []*model.IncidentReport
+}
+
+// groupIRsByMonth groups the incidents by their start month, sorted by the latest month first.
+// The incidents are expected to be sorted by the latest first (like the history).
+func groupIRsByMonth(irs []*model.IncidentReport) []archiveMonth {
+	months := []archiveMonth{}
+	for _, ir := range irs {
+		start := ir.Start.UTC()
+		i := slices.IndexFunc(months, func(m archiveMonth) bool { return m.Year == start.Year() && m.Month == start.Month() })
+		if i < 0 {
+			months = append(months, archiveMonth{Year: start.Year(), Month: start.Month()})
+			i = len(months) - 1
+		}
+		months[i].IRs = append(months[i].IRs, ir)
+	}
+
+	slices.SortStableFunc(months, func(a, b archiveMonth) int {
+		if a.Year != b.Year {
+			return b.Year - a.Year
+		}
+		return int(b.Month) - int(a.Month)
+	})
+
+	return months
+}
+
+type archiveMonthTplData struct {
+	Name   string
+	URL    string
+	IRs    int
+	Impact string
+}
+
+type archiveYearTplData struct {
+	Year   int
+	Months []archiveMonthTplData
+}
+
+// newArchiveTplData returns the archive months grouped by year, with the number of incidents and the worst impact of each month.
+func (g Generator) newArchiveTplData(tplCommon tplCommonData, months []archiveMonth) []archiveYearTplData {
+	years := []archiveYearTplData{}
+	for _, m := range months {
+		if len(years) == 0 || years[len(years)-1].Year != m.Year {
+			years = append(years, archiveYearTplData{Year: m.Year})
+		}
+
+		month := archiveMonthTplData{
+			Name: g.renderer.Translate(fmt.Sprintf("month_short_%d", m.Month)),
+			URL:  conventions.IRHistoryArchiveURL(tplCommon.URLPrefix, m.Year, m.Month),
+			IRs:  len(m.IRs),
+		}
+		worst := -1
+		for _, ir := range m.IRs {
+			if ir.Impact.Level() > worst {
+				worst = ir.Impact.Level()
+				month.Impact = string(ir.Impact)
+			}
+		}
+
+		years[len(years)-1].Months = append(years[len(years)-1].Months, month)
+	}
+
+	return years
+}
+
+// genSearch will generate the search page and its static search index. The index is split
+// in shards (latest incidents first) so the browser can search progressively without
+// downloading the whole history at once.
+func (g Generator) genSearch(ctx context.Context, ui model.UI, tplCommon tplCommonData) error {
+	type tplData struct {
+		tplCommonData
+		SearchIndexURL string
+	}
+
+	// Shards.
+	manifest := searchIndexManifest{
+		Version: 1,
+		Total:   len(ui.History),
+		IRURL:   conventions.IRDetailURL(tplCommon.URLPrefix, searchIndexIRIDPlaceholder),
+		Systems: map[string]string{},
+		Shards:  []string{},
+	}
+	for _, s := range ui.SystemDetails {
+		manifest.Systems[s.System.ID] = s.System.Name
+	}
+
+	for shard, i := 0, 0; i < len(ui.History); shard, i = shard+1, i+g.searchIRsPerShard {
+		end := min(i+g.searchIRsPerShard, len(ui.History))
+
+		entries := []searchIndexEntry{}
+		for _, ir := range ui.History[i:end] {
+			entry, err := newSearchIndexEntry(ir)
+			if err != nil {
+				return fmt.Errorf("could not index %q incident: %w", ir.ID, err)
+			}
+			entries = append(entries, entry)
+		}
+
+		data, err := json.Marshal(entries)
+		if err != nil {
+			return fmt.Errorf("could not marshal search index shard: %w", err)
+		}
+
+		err = g.fileManager.WriteFile(ctx, conventions.SearchIndexShardFilePath(g.outPath, shard), data)
+		if err != nil {
+			return fmt.Errorf("could not write search index shard: %w", err)
+		}
+
+		manifest.Shards = append(manifest.Shards, conventions.SearchIndexShardURL(tplCommon.URLPrefix, shard))
+	}
+
+	// Manifest.
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("could not marshal search index: %w", err)
+	}
+
+	err = g.fileManager.WriteFile(ctx, conventions.SearchIndexFilePath(g.outPath), data)
+	if err != nil {
+		return fmt.Errorf("could not write search index: %w", err)
+	}
+
+	// Search page.
+	page, err := g.renderer.Render(ctx, "page_search", tplData{
+		tplCommonData: tplCommon.withMeta(pageMetaTplData{
+			Title:        g.renderer.Translate("search_title"),
+			Description:  g.renderer.Translate("meta_search_description", ui.Settings.Name),
+			CanonicalURL: tplCommon.SearchURL,
+		}),
+		SearchIndexURL: conventions.SearchIndexURL(tplCommon.URLPrefix),
+	})
+	if err != nil {
+		return fmt.Errorf("could not render search: %w", err)
+	}
+
+	err = g.fileManager.WriteFile(ctx, conventions.SearchFilePath(g.outPath), []byte(page))
+	if err != nil {
+		return fmt.Errorf("could not write search: %w", err)
+	}
+
+	return nil
+}
+
+// searchIndexIRIDPlaceholder is the placeholder of the incident ID on the manifest incident URL pattern.
+const searchIndexIRIDPlaceholder = "{id}"
+
+// searchIndexManifest is the entrypoint of the search index, has the shards URLs
+// (latest incidents first) and the data shared by all the entries.
+type searchIndexManifest struct {
+	Version int               `json:"version"`
+	Total   int               `json:"total"`
+	IRURL   string            `json:"irURL"`   // Incident URL pattern, the ID placeholder is replaced by the entry ID.
+	Systems map[string]string `json:"systems"` // ID -> Name.
+	Shards  []string          `json:"shards"`
+}
+
+// searchIndexEntry is an incident on the search index, uses short keys to keep the index small.
+type searchIndexEntry struct {
+	ID        string   `json:"i"`
+	Name      string   `json:"n"`
+	SystemIDs []string `json:"s,omitempty"`
+	Impact    string   `json:"m"`
+	Start     int64    `json:"st"`
+	End       int64    `json:"en,omitempty"` // Missing if ongoing.
+	Text      string   `json:"t,omitempty"`  // Plain text timeline.
+}
+
+func newSearchIndexEntry(ir *model.IncidentReport) (searchIndexEntry, error) {
+	texts := []string{}
+	for _, e := range ir.Timeline {
+		t, err := utilhtml.RenderMarkdownToText(e.Description)
+		if err != nil {
+			return searchIndexEntry{}, err
+		}
+		if t != "" {
+			texts = append(texts, t)
+		}
+	}
+
+	entry := searchIndexEntry{
+		ID:        ir.ID,
+		Name:      ir.Name,
+		SystemIDs: ir.SystemIDs,
+		Impact:    string(ir.Impact),
+		Start:     ir.Start.Unix(),
+		Text:      strings.Join(texts, " "),
+	}
+	if !ir.End.IsZero() {
+		entry.End = ir.End.Unix()
+	}
+
+	return entry, nil
+}
+
 // genIRs will generate the incident report files.
-func (g Generator) genIRs(ctx context.Context, ui model.UI, tplCommon tplCommonData) error {
+func (g Generator) genIRs(ctx context.Context, ui model.UI, tplCommon tplCommonData, markdown *utilhtml.MarkdownRenderer) error {
 	type timelineTplData struct {
-		Kind   string
-		TS     time.Time
-		Detail template.HTML
+		Kind        string
+		TS          time.Time
+		Detail      template.HTML
+		PrivateNote template.HTML
+	}
+
+	type postmortemTplData struct {
+		TS      time.Time
+		Content template.HTML
+		TOC     []utilhtml.MarkdownHeading
 	}
 
 	type tplData struct {
 		tplCommonData
-		Title    string
-		ID       string
-		Impact   string
-		StartTS  time.Time
-		EndTS    time.Time
-		Duration time.Duration
-		Timeline []timelineTplData
+		Title      string
+		ID         string
+		Impact     string
+		StartTS    time.Time
+		EndTS      time.Time
+		Duration   time.Duration
+		Timeline   []timelineTplData
+		Draft      bool
+		Postmortem *postmortemTplData
+	}
+
+	systemNames := map[string]string{}
+	for _, s := range ui.SystemDetails {
+		systemNames[s.System.ID] = s.System.Name
 	}
 
 	// Render a IR per page.
@@ -327,27 +875,58 @@ func (g Generator) genIRs(ctx context.Context, ui model.UI, tplCommon tplCommonD
 
 		timeline := []timelineTplData{}
 		for _, d := range ir.Timeline {
-			md, err := utilhtml.RenderMarkdownToHTML(d.Description)
+			md, err := markdown.RenderToHTML(d.Description, utilhtml.AssetURLResolver(tplCommon.SiteURL, d.Assets))
 			if err != nil {
 				return fmt.Errorf("could not render markdown: %w", err)
 			}
 
+			var privateNote template.HTML
+			if d.PrivateNote != "" {
+				privateNote, err = markdown.RenderToHTML(d.PrivateNote, utilhtml.AssetURLResolver(tplCommon.SiteURL, d.PrivateNoteAssets))
+				if err != nil {
+					return fmt.Errorf("could not render markdown: %w", err)
+				}
+			}
+
 			timeline = append(timeline, timelineTplData{
-				Kind:   string(d.Kind),
-				TS:     d.TS,
-				Detail: md,
+				Kind:        string(d.Kind),
+				TS:          d.TS,
+				Detail:      md,
+				PrivateNote: privateNote,
 			})
 		}
 
+		var postmortem *postmortemTplData
+		if pm := ir.Postmortem; pm != nil {
+			content, headings, err := markdown.RenderDocumentToHTML(pm.Content, utilhtml.AssetURLResolver(tplCommon.SiteURL, pm.Assets))
+			if err != nil {
+				return fmt.Errorf("could not render postmortem markdown: %w", err)
+			}
+
+			postmortem = &postmortemTplData{
+				TS:      pm.TS,
+				Content: content,
+				TOC:     headings,
+			}
+		}
+
 		data := tplData{
-			tplCommonData: tplCommon,
-			Title:         ir.Name,
-			ID:            ir.ID,
-			Impact:        string(ir.Impact),
-			StartTS:       ir.Start,
-			EndTS:         ir.End,
-			Duration:      duration,
-			Timeline:      timeline,
+			tplCommonData: tplCommon.withMeta(pageMetaTplData{
+				Title:        ir.Name,
+				Description:  g.irDescription(ir, systemNames),
+				CanonicalURL: conventions.IRDetailURL(tplCommon.URLPrefix, ir.ID),
+				Type:         "article",
+				ImageURL:     conventions.IROGImageURL(tplCommon.SiteURL, ir.ID),
+			}),
+			Title:      ir.Name,
+			ID:         ir.ID,
+			Impact:     string(ir.Impact),
+			StartTS:    ir.Start,
+			EndTS:      ir.End,
+			Duration:   duration,
+			Timeline:   timeline,
+			Draft:      ir.Visibility == model.IncidentVisibilityDraft,
+			Postmortem: postmortem,
 		}
 
 		// Render history first page.
@@ -365,10 +944,507 @@ func (g Generator) genIRs(ctx context.Context, ui model.UI, tplCommon tplCommonD
 	return nil
 }
 
+// genSystems will generate the system detail files.
+func (g Generator) genSystems(ctx context.Context, ui model.UI, tplCommon tplCommonData) error {
+	type incidentTplData struct {
+		Title      string
+		URL        string
+		StartTS    time.Time
+		EndTS      time.Time
+		Impact     string
+		Draft      bool
+		Postmortem bool
+	}
+
+	type uptimeDayTplData struct {
+		TS     time.Time
+		IRs    int
+		Impact string
+	}
+
+	type tplData struct {
+		tplCommonData
+		ID            string
+		Name          string
+		Description   string
+		OK            bool
+		Impact        string
+		TotalIRs      int
+		MTTR          time.Duration
+		UptimePercent string
+		UptimeDays    []uptimeDayTplData
+		NextURL       string
+		PreviousURL   string
+		Incidents     []incidentTplData
+	}
+
+	now := g.timeNow()
+	for _, s := range ui.SystemDetails {
+		// Advertise the system specific feeds.
+		systemFeeds := newSystemFeedTplData(tplCommon, s.System)
+		systemTplCommon := tplCommon
+		systemTplCommon.SystemHistoryFeed = &systemFeeds
+
+		// Calculate uptime history by day.
+		uptimeDays := []uptimeDayTplData{}
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		windowStart := today.AddDate(0, 0, -(g.systemUptimeDays - 1))
+		for i := 0; i < g.systemUptimeDays; i++ {
+			dayStart := windowStart.AddDate(0, 0, i)
+			dayEnd := dayStart.AddDate(0, 0, 1)
+			day := uptimeDayTplData{TS: dayStart, Impact: "ok"}
+			worst := -1
+			for _, ir := range s.IRs {
+				if !irOverlaps(ir, dayStart, dayEnd, now) {
+					continue
+				}
+				day.IRs++
+				if ir.Impact.Level() > worst {
+					worst = ir.Impact.Level()
+					day.Impact = string(ir.Impact)
+				}
+			}
+			uptimeDays = append(uptimeDays, day)
+		}
+
+		// Uptime based on the time the system had incidents on the window.
+		uptime := systemUptime(s.IRs, windowStart, now)
+
+		pageIncidents := splitSystemIRPages(s.IRs, g.historyIRPerPage)
+
+		for i, page := range pageIncidents {
+			nextURL := conventions.SystemIRHistoryURL(tplCommon.URLPrefix, s.System.ID, i-1)
+			previousURL := conventions.SystemIRHistoryURL(tplCommon.URLPrefix, s.System.ID, i+1)
+
+			// Special page cases (first, last).
+			if i == 0 {
+				nextURL = ""
+			}
+			if len(pageIncidents)-1 == i {
+				previousURL = ""
+			}
+
+			incidents := []incidentTplData{}
+			for _, ir := range page {
+				incidents = append(incidents, incidentTplData{
+					Title:      ir.Name,
+					URL:        conventions.IRDetailURL(tplCommon.URLPrefix, ir.ID),
+					StartTS:    ir.Start,
+					EndTS:      ir.End,
+					Impact:     string(ir.Impact),
+					Draft:      ir.Visibility == model.IncidentVisibilityDraft,
+					Postmortem: ir.Postmortem != nil,
+				})
+			}
+
+			data := tplData{
+				tplCommonData: systemTplCommon.withMeta(pageMetaTplData{
+					Title:        s.System.Name,
+					Description:  g.systemDescription(s),
+					CanonicalURL: conventions.SystemIRHistoryURL(tplCommon.URLPrefix, s.System.ID, i),
+				}),
+				ID:            s.System.ID,
+				Name:          s.System.Name,
+				Description:   s.System.Description,
+				OK:            s.Status.Operational,
+				Impact:        string(s.Status.Impact),
+				TotalIRs:      s.Stats.TotalIRs,
+				MTTR:          s.Stats.MTTR.Round(time.Second),
+				UptimePercent: strconv.FormatFloat(uptime, 'f', 2, 64),
+				UptimeDays:    uptimeDays,
+				NextURL:       nextURL,
+				PreviousURL:   previousURL,
+				Incidents:     incidents,
+			}
+
+			system, err := g.renderer.Render(ctx, "page_system", data)
+			if err != nil {
+				return fmt.Errorf("could not render %q system: %w", s.System.ID, err)
+			}
+
+			err = g.fileManager.WriteFile(ctx, conventions.SystemIRHistoryFilePath(g.outPath, s.System.ID, i), []byte(system))
+			if err != nil {
+				return fmt.Errorf("could not write %q system: %w", s.System.ID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// systemUptime returns the uptime percentage of the [windowStart, now) window, the incidents
+// without impact are ignored and the overlapping incidents are merged so the downtime is not
+// counted multiple times.
+func systemUptime(irs []*model.IncidentReport, windowStart, now time.Time) float64 {
+	window := now.Sub(windowStart)
+	if window <= 0 {
+		return 100
+	}
+
+	type interval struct{ start, end time.Time }
+	intervals := []interval{}
+	for _, ir := range irs {
+		if ir.Impact == model.IncidentImpactNone {
+			continue
+		}
+
+		start, end := ir.Start, ir.End
+		if end.IsZero() || end.After(now) {
+			end = now
+		}
+		if start.Before(windowStart) {
+			start = windowStart
+		}
+		if end.After(start) {
+			intervals = append(intervals, interval{start: start, end: end})
+		}
+	}
+	slices.SortFunc(intervals, func(a, b interval) int { return a.start.Compare(b.start) })
+
+	var downtime time.Duration
+	var current *interval
+	for _, i := range intervals {
+		if current != nil && !i.start.After(current.end) {
+			if i.end.After(current.end) {
+				current.end = i.end
+			}
+			continue
+		}
+		if current != nil {
+			downtime += current.end.Sub(current.start)
+		}
+		current = &interval{start: i.start, end: i.end}
+	}
+	if current != nil {
+		downtime += current.end.Sub(current.start)
+	}
+
+	uptime := 100 * (1 - float64(downtime)/float64(window))
+	if uptime < 0 {
+		uptime = 0
+	}
+
+	return uptime
+}
+
+// irOverlaps returns true if the incident was active at some point in the [start, end) range,
+// ongoing incidents are considered active until now.
+func irOverlaps(ir *model.IncidentReport, start, end, now time.Time) bool {
+	irEnd := ir.End
+	if irEnd.IsZero() {
+		irEnd = now
+	}
+
+	return ir.Start.Before(end) && !irEnd.Before(start)
+}
+
+// genPages will generate the custom content pages.
+func (g Generator) genPages(ctx context.Context, ui model.UI, tplCommon tplCommonData, markdown *utilhtml.MarkdownRenderer) error {
+	type tplData struct {
+		tplCommonData
+		Title   string
+		Slug    string
+		Content template.HTML
+	}
+
+	for _, p := range ui.Pages {
+		content, err := markdown.RenderToHTML(p.Content, nil)
+		if err != nil {
+			return fmt.Errorf("could not render markdown: %w", err)
+		}
+
+		data := tplData{
+			tplCommonData: tplCommon.withMeta(pageMetaTplData{
+				Title:        p.Title,
+				Description:  g.siteDescription(ui),
+				CanonicalURL: conventions.PageURL(tplCommon.URLPrefix, p.Slug),
+			}),
+			Title:   p.Title,
+			Slug:    p.Slug,
+			Content: content,
+		}
+
+		page, err := g.renderer.Render(ctx, "page_custom", data)
+		if err != nil {
+			return fmt.Errorf("could not render %q page: %w", p.Slug, err)
+		}
+
+		err = g.fileManager.WriteFile(ctx, conventions.PageFilePath(g.outPath, p.Slug), []byte(page))
+		if err != nil {
+			return fmt.Errorf("could not write %q page: %w", p.Slug, err)
+		}
+	}
+
+	return nil
+}
+
+// genSitemap will generate the sitemap with all the generated pages (of all the languages) and the robots file.
+func (g Generator) genSitemap(ctx context.Context, ui model.UI, siteURL string, languages []languageTplData) error {
+	robots := ui.Settings.SEO.Robots
+	if robots == "" {
+		robots = "User-agent: *\nAllow: /\n\nSitemap: " + siteURL + "/" + conventions.SitemapPathName + "\n"
+		if ui.Settings.SEO.NoIndex {
+			robots = "User-agent: *\nDisallow: /\n"
+		}
+	}
+
+	err := g.fileManager.WriteFile(ctx, g.outPath+conventions.RobotsPathName, []byte(robots))
+	if err != nil {
+		return fmt.Errorf("could not write robots: %w", err)
+	}
+
+	// We don't want the pages to be discovered if the status page should not be indexed.
+	if ui.Settings.SEO.NoIndex {
+		return nil
+	}
+
+	sitemap := sitemapURLSet{XMLNS: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+	addURL := func(loc string, lastMod time.Time) {
+		u := sitemapURL{Loc: loc}
+		if !lastMod.IsZero() {
+			u.LastMod = lastMod.UTC().Format(time.RFC3339)
+		}
+		sitemap.URLs = append(sitemap.URLs, u)
+	}
+
+	for _, l := range languages {
+		urlPrefix := l.SiteURL
+		addURL(urlPrefix+"/", time.Time{})
+		for i := range splitIRPages(ui.History, g.historyIRPerPage) {
+			addURL(conventions.IRHistoryURL(urlPrefix, i), time.Time{})
+		}
+		for _, m := range groupIRsByMonth(ui.History) {
+			addURL(conventions.IRHistoryArchiveURL(urlPrefix, m.Year, m.Month), time.Time{})
+		}
+		addURL(conventions.SearchURL(urlPrefix), time.Time{})
+		for _, ir := range ui.History {
+			addURL(conventions.IRDetailURL(urlPrefix, ir.ID), irLastUpdate(ir))
+		}
+		for _, s := range ui.SystemDetails {
+			for i := range splitSystemIRPages(s.IRs, g.historyIRPerPage) {
+				addURL(conventions.SystemIRHistoryURL(urlPrefix, s.System.ID, i), time.Time{})
+			}
+		}
+		for _, p := range ui.Pages {
+			addURL(conventions.PageURL(urlPrefix, p.Slug), time.Time{})
+		}
+	}
+
+	data, err := xml.MarshalIndent(sitemap, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not marshal sitemap: %w", err)
+	}
+
+	err = g.fileManager.WriteFile(ctx, g.outPath+conventions.SitemapPathName, append([]byte(xml.Header), data...))
+	if err != nil {
+		return fmt.Errorf("could not write sitemap: %w", err)
+	}
+
+	return nil
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// splitIRPages splits the incidents in pages of the history.
+func splitIRPages(irs []*model.IncidentReport, perPage int) [][]*model.IncidentReport {
+	pages := [][]*model.IncidentReport{}
+	for i := 0; i < len(irs); i += perPage {
+		end := min(i+perPage, len(irs))
+		pages = append(pages, irs[i:end])
+	}
+
+	return pages
+}
+
+// splitSystemIRPages is like splitIRPages but always has at least one page, as the system detail
+// page is rendered even without incidents.
+func splitSystemIRPages(irs []*model.IncidentReport, perPage int) [][]*model.IncidentReport {
+	pages := splitIRPages(irs, perPage)
+	if len(pages) == 0 {
+		pages = append(pages, []*model.IncidentReport{})
+	}
+
+	return pages
+}
+
+// irLastUpdate returns the latest time the incident was updated.
+func irLastUpdate(ir *model.IncidentReport) time.Time {
+	last := ir.Start
+	for _, e := range ir.Timeline {
+		if e.TS.After(last) {
+			last = e.TS
+		}
+	}
+	if ir.End.After(last) {
+		last = ir.End
+	}
+
+	return last
+}
+
+// siteDescription returns the configured status page description or one based on the current status.
+func (g Generator) siteDescription(ui model.UI) string {
+	if ui.Settings.SEO.Description != "" {
+		return ui.Settings.SEO.Description
+	}
+
+	if len(ui.OpenedIRs) == 0 {
+		return g.renderer.Translate("meta_site_operational", ui.Settings.Name)
+	}
+
+	names := []string{}
+	for _, ir := range ui.OpenedIRs {
+		names = append(names, ir.Name)
+	}
+
+	return g.renderer.Translate("meta_site_ongoing", ui.Settings.Name, len(ui.OpenedIRs), strings.Join(names, ", "))
+}
+
+func (g Generator) irDescription(ir *model.IncidentReport, systemNames map[string]string) string {
+	impact := g.renderer.Translate("incident_impact_" + string(ir.Impact))
+	desc := g.renderer.Translate("meta_ir_resolved", impact)
+	if ir.End.IsZero() {
+		desc = g.renderer.Translate("meta_ir_ongoing", impact)
+	}
+
+	if len(ir.SystemIDs) > 0 {
+		systems := []string{}
+		for _, id := range ir.SystemIDs {
+			name, ok := systemNames[id]
+			if !ok {
+				name = id
+			}
+			systems = append(systems, name)
+		}
+		desc += g.renderer.Translate("meta_ir_affecting", strings.Join(systems, ", "))
+	}
+
+	return g.renderer.Translate("meta_ir_started", desc, ir.Start.UTC().Format("2006-01-02 15:04"))
+}
+
+func (g Generator) systemDescription(s model.SystemDetails) string {
+	desc := g.renderer.Translate("meta_system_operational", s.System.Name)
+	if !s.Status.Operational {
+		impact := g.renderer.Translate("incident_impact_" + string(s.Status.Impact))
+		desc = g.renderer.Translate("meta_system_degraded", s.System.Name, impact)
+	}
+
+	if s.System.Description != "" {
+		desc += " " + s.System.Description
+	}
+
+	return desc
+}
+
+type systemFeedTplData struct {
+	Name    string
+	AtomURL string
+	RSSURL  string
+	JSONURL string
+}
+
+// newSystemFeedTplData returns the system feed URLs for the enabled feed formats.
+func newSystemFeedTplData(tplCommon tplCommonData, system model.System) systemFeedTplData {
+	d := systemFeedTplData{Name: system.Name}
+	if tplCommon.AtomHistoryFeedPath != "" {
+		d.AtomURL = conventions.SystemIRHistoryFeedURL(tplCommon.SiteURL, system.ID, tplCommon.AtomHistoryFeedPath)
+	}
+	if tplCommon.RSSHistoryFeedPath != "" {
+		d.RSSURL = conventions.SystemIRHistoryFeedURL(tplCommon.SiteURL, system.ID, tplCommon.RSSHistoryFeedPath)
+	}
+	if tplCommon.JSONHistoryFeedPath != "" {
+		d.JSONURL = conventions.SystemIRHistoryFeedURL(tplCommon.SiteURL, system.ID, tplCommon.JSONHistoryFeedPath)
+	}
+
+	return d
+}
+
+type navPageTplData struct {
+	Title string
+	URL   string
+}
+
+type languageTplData struct {
+	Code    string
+	Name    string // In its own language.
+	SiteURL string // Root of the language site.
+	URL     string // Current page on the language site.
+	Current bool
+}
+
 type tplCommonData struct {
+	// URLPrefix is the root of the site in the current language, SiteURL is the root
+	// of the whole site, where the shared resources are (static files, feeds...).
 	URLPrefix             string
+	SiteURL               string
 	BrandTitle            string
 	HistoryURL            string
+	SearchURL             string
 	PrometheusMetricsPath string
-	AtomHistoryFeedPath   string
+	NavPages              []navPageTplData
+	// StatusImpact is the worst impact of the open incidents (`ok` if none).
+	StatusImpact string
+
+	// Feeds paths, empty if disabled.
+	AtomHistoryFeedPath string
+	RSSHistoryFeedPath  string
+	JSONHistoryFeedPath string
+	// Per system feeds.
+	SystemHistoryFeeds []systemFeedTplData
+	// Only set when the page is in the context of a specific system.
+	SystemHistoryFeed *systemFeedTplData
+
+	// SEO.
+	NoIndex bool
+	Meta    pageMetaTplData
+
+	// I18n.
+	Lang         string
+	LanguageName string
+	// Languages of the site with the current page URLs, empty if only one language.
+	Languages []languageTplData
+
+	// Color scheme.
+	ColorScheme  string // `light` or `dark`, empty to follow the user preferences.
+	HighContrast bool
+}
+
+// pageMetaTplData is the page specific metadata (description, canonical URL, OpenGraph...).
+type pageMetaTplData struct {
+	Title        string // Without the brand, empty on the main page.
+	Description  string
+	CanonicalURL string
+	Type         string // OpenGraph type.
+	ImageURL     string // OpenGraph preview image.
+}
+
+// withMeta returns the common data with the page metadata set.
+func (t tplCommonData) withMeta(meta pageMetaTplData) tplCommonData {
+	if meta.Type == "" {
+		meta.Type = "website"
+	}
+	if meta.ImageURL == "" {
+		meta.ImageURL = conventions.IndexOGImageURL(t.SiteURL)
+	}
+	t.Meta = meta
+
+	// The same page on the other languages.
+	path := strings.TrimPrefix(meta.CanonicalURL, t.URLPrefix)
+	languages := make([]languageTplData, 0, len(t.Languages))
+	for _, l := range t.Languages {
+		l.URL = l.SiteURL + path
+		languages = append(languages, l)
+	}
+	t.Languages = languages
+
+	return t
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/slok/stactus/internal/model"
+	"github.com/slok/stactus/internal/storage/html/common"
 	"github.com/slok/stactus/internal/storage/html/themes/simple"
 	utilfs "github.com/slok/stactus/internal/util/fs"
 )
@@ -17,9 +18,12 @@ func TestCreateUI(t *testing.T) {
 	t0, _ := time.Parse(time.RFC3339, "1912-06-23T01:02:03Z")
 
 	tests := map[string]struct {
-		ui         model.UI
-		expectHTML map[string][]string
-		expErr     bool
+		ui             model.UI
+		messages       common.Messages
+		expectHTML     map[string][]string
+		expNotContains map[string][]string
+		expNotExist    []string
+		expErr         bool
 	}{
 		"The static files have been rendered correctly.": {
 			ui: model.UI{
@@ -64,6 +68,48 @@ func TestCreateUI(t *testing.T) {
 					`Subscribe to updates!`,
 					`<a href="https://monkeyisland.slok.dev/metrics">Prometheus metrics</a>`,
 					`<a href="https://monkeyisland.slok.dev/history-feed.atom">Atom feed</a>`,
+					`<a href="https://monkeyisland.slok.dev/history-feed.rss">RSS feed</a>`,
+					`<a href="https://monkeyisland.slok.dev/history-feed.json">JSON feed</a>`,
+				},
+			},
+		},
+
+		"The subscription dialog should have the per system feeds.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{
+					Name: "MonkeyIsland",
+					URL:  "https://monkeyisland.slok.dev",
+				},
+				SystemDetails: []model.SystemDetails{
+					{System: model.System{ID: "test1", Name: "Test 1"}, Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone}},
+					{System: model.System{ID: "test2", Name: "Test 2"}, Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone}},
+				},
+			},
+			expectHTML: map[string][]string{
+				"./index.html": {
+					`<summary> <img height="16" width="16" src="https://cdn.jsdelivr.net/npm/simple-icons@v13/icons/rss.svg" /> Per system feeds </summary>`,
+					`<li> Test 1: <a href="https://monkeyisland.slok.dev/system/test1/history-feed.atom">Atom</a> <a href="https://monkeyisland.slok.dev/system/test1/history-feed.rss">RSS</a> <a href="https://monkeyisland.slok.dev/system/test1/history-feed.json">JSON</a> </li>`,
+					`<li> Test 2: <a href="https://monkeyisland.slok.dev/system/test2/history-feed.atom">Atom</a> <a href="https://monkeyisland.slok.dev/system/test2/history-feed.rss">RSS</a> <a href="https://monkeyisland.slok.dev/system/test2/history-feed.json">JSON</a> </li>`,
+				},
+			},
+		},
+
+		"The subscription dialog should only have the enabled feed formats.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{
+					Name: "MonkeyIsland",
+					URL:  "https://monkeyisland.slok.dev",
+					Feed: model.FeedSettings{DisableAtom: true, DisableJSON: true},
+				},
+				SystemDetails: []model.SystemDetails{
+					{System: model.System{ID: "test1", Name: "Test 1"}, Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone}},
+				},
+			},
+			expectHTML: map[string][]string{
+				"./index.html": {
+					`<link rel=alternate title="Incident history" type=application/rss+xml href="https://monkeyisland.slok.dev/history-feed.rss"> </head>`,
+					`<a href="https://monkeyisland.slok.dev/metrics">Prometheus metrics</a> </li> <li> <img height="16" width="16" src="https://cdn.jsdelivr.net/npm/simple-icons@v13/icons/rss.svg" /> <a href="https://monkeyisland.slok.dev/history-feed.rss">RSS feed</a> </li> <li> <details>`,
+					`<li> Test 1: <a href="https://monkeyisland.slok.dev/system/test1/history-feed.rss">RSS</a> </li>`,
 				},
 			},
 		},
@@ -74,15 +120,19 @@ func TestCreateUI(t *testing.T) {
 					Name: "MonkeyIsland",
 					URL:  "https://monkeyisland.slok.dev",
 				},
+				Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
 				SystemDetails: []model.SystemDetails{
 					{
 						System: model.System{ID: "test1", Name: "Test 1", Description: "Something test 1"},
+						Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
 					},
 					{
 						System: model.System{ID: "test2", Name: "Test 2", Description: "Something test 2"},
+						Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
 					},
 					{
 						System: model.System{ID: "test3", Name: "Test 3", Description: "Something test 3"},
+						Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
 					},
 				},
 			},
@@ -92,10 +142,11 @@ func TestCreateUI(t *testing.T) {
 					`Powered by <a href="https://github.com/slok/stactus">Stactus</a>.`, // We have the footer.
 
 					// Status is ok.
+					`<meta name="theme-color" content="#1A7F37" />`,
 					`<strong>All systems operational</strong>`,
-					`<article> Test 1 <span data-tooltip="Something test 1"><i class="ph-thin ph-question"></i></span><span class="move-right"> <i style="font-size: 150%;" class="ph-fill ph-check-circle text-ok"></i> </span><div> <small> Normal </small> </div> </article>`,
-					`<article> Test 2 <span data-tooltip="Something test 2"><i class="ph-thin ph-question"></i></span><span class="move-right"> <i style="font-size: 150%;" class="ph-fill ph-check-circle text-ok"></i> </span><div> <small> Normal </small> </div> </article>`,
-					`<article> Test 3 <span data-tooltip="Something test 3"><i class="ph-thin ph-question"></i></span><span class="move-right"> <i style="font-size: 150%;" class="ph-fill ph-check-circle text-ok"></i> </span><div> <small> Normal </small> </div> </article>`,
+					`<article> <a href="https://monkeyisland.slok.dev/system/test1" class="system-link">Test 1</a> <span data-tooltip="Something test 1"><i class="ph-thin ph-question" aria-hidden="true"></i></span><span class="move-right" style="font-size: 150%;"> <i class="ph-fill ph-check-circle impact-icon impact-icon-ok" aria-hidden="true"></i> </span><div> <small> Normal </small> </div> </article>`,
+					`<article> <a href="https://monkeyisland.slok.dev/system/test2" class="system-link">Test 2</a> <span data-tooltip="Something test 2"><i class="ph-thin ph-question" aria-hidden="true"></i></span><span class="move-right" style="font-size: 150%;"> <i class="ph-fill ph-check-circle impact-icon impact-icon-ok" aria-hidden="true"></i> </span><div> <small> Normal </small> </div> </article>`,
+					`<article> <a href="https://monkeyisland.slok.dev/system/test3" class="system-link">Test 3</a> <span data-tooltip="Something test 3"><i class="ph-thin ph-question" aria-hidden="true"></i></span><span class="move-right" style="font-size: 150%;"> <i class="ph-fill ph-check-circle impact-icon impact-icon-ok" aria-hidden="true"></i> </span><div> <small> Normal </small> </div> </article>`,
 				},
 			},
 		},
@@ -106,6 +157,7 @@ func TestCreateUI(t *testing.T) {
 					Name: "MonkeyIsland",
 					URL:  "https://monkeyisland.slok.dev",
 				},
+				Status: model.SystemStatus{Impact: model.IncidentImpactCritical, OpenIRIDs: []string{"ir42", "ir99"}},
 				OpenedIRs: []*model.IncidentReport{
 					{
 						ID:        "ir42",
@@ -135,36 +187,422 @@ func TestCreateUI(t *testing.T) {
 				SystemDetails: []model.SystemDetails{
 					{
 						System: model.System{ID: "test1", Name: "Test 1", Description: "Something test 1"},
+						Status: model.SystemStatus{Impact: model.IncidentImpactCritical, OpenIRIDs: []string{"ir42"}},
 					},
 					{
 						System: model.System{ID: "test2", Name: "Test 2", Description: "Something test 2"},
+						Status: model.SystemStatus{Impact: model.IncidentImpactMajor, OpenIRIDs: []string{"ir99"}},
+						IRs: []*model.IncidentReport{
+							{ID: "ir99", SystemIDs: []string{"test2"}, Start: t0, Impact: model.IncidentImpactMajor},
+							{ID: "ir12", SystemIDs: []string{"test2", "test3"}, Start: t0.Add(-2 * time.Hour), End: t0.Add(-1 * time.Hour), Impact: model.IncidentImpactMinor},
+						},
 					},
 					{
 						System:   model.System{ID: "test3", Name: "Test 3", Description: "Something test 3"},
-						LatestIR: &model.IncidentReport{},
+						LatestIR: &model.IncidentReport{ID: "ir12", SystemIDs: []string{"test2", "test3"}, Start: t0.Add(-2 * time.Hour), End: t0.Add(-1 * time.Hour), Impact: model.IncidentImpactMinor},
+						Status:   model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
 					},
 				},
 			},
 			expectHTML: map[string][]string{
 				"./index.html": {
+					`<meta name="theme-color" content="#CF222E" />`, // Worst open incident impact color.
+
 					// Ongoing incident 1 info.
-					`<article class="box-impact-critical"> <header class="header-impact-critical">`,                 // We have the impact.
-					`<h4><a href="https://monkeyisland.slok.dev/ir/ir42" class="incident-title"> Oh snap!</a></h4>`, // We have the title and link
+					`<article class="box-impact-critical"> <header class="header-impact-critical">`,                                          // We have the impact.
+					`<h4><a href="https://monkeyisland.slok.dev/ir/ir42" class="incident-title"> Oh snap!</a></h4>`,                          // We have the title and link
+					`<small><i class="ph-fill ph-x-circle impact-icon impact-icon-critical" aria-hidden="true"></i> Critical impact</small>`, // Impact is not only shown with colors.
 					`<p>There is a problem 3</p>`, // Regular plain description.
-					`<small>Latest update at <span x-init="renderTSUnixPrettyNoYear($el)">-1815344697</span></small>`, // TS set for client JS libs.
+					`<small>Latest update at <span x-init="renderTSUnixPrettyNoYear($el)">-1815344697</span> (1 day ago)</small>`, // TS set for client JS libs.
 
 					// Ongoing incident 2 info.
-					`<article class="box-impact-major"> <header class="header-impact-major">`,                           // We have the impact.
-					`<h4><a href="https://monkeyisland.slok.dev/ir/ir99" class="incident-title"> fuuuuuuuuuuu</a></h4>`, // We have the title and link
-					`<p>something <strong>something</strong> 9</p>`,                                                     //  Markdown description.
-					`<small>Latest update at <span x-init="renderTSUnixPrettyNoYear($el)">-1815334737</span></small>`,   // TS set for client JS libs.
+					`<article class="box-impact-major"> <header class="header-impact-major">`,                                     // We have the impact.
+					`<h4><a href="https://monkeyisland.slok.dev/ir/ir99" class="incident-title"> fuuuuuuuuuuu</a></h4>`,           // We have the title and link
+					`<p>something <strong>something</strong> 9</p>`,                                                               //  Markdown description.
+					`<small>Latest update at <span x-init="renderTSUnixPrettyNoYear($el)">-1815334737</span> (1 day ago)</small>`, // TS set for client JS libs.
+
+					// Systems status.
+					`<article> <a href="https://monkeyisland.slok.dev/system/test1" class="system-link">Test 1</a> <span data-tooltip="Something test 1"><i class="ph-thin ph-question" aria-hidden="true"></i></span><span class="move-right" style="font-size: 150%;"> <i class="ph-fill ph-x-circle impact-icon impact-icon-critical" aria-hidden="true"></i> </span><div> <small> Degraded (Critical impact) </small> </div> </article>`,
+					`<article> <a href="https://monkeyisland.slok.dev/system/test2" class="system-link">Test 2</a> <span data-tooltip="Something test 2"><i class="ph-thin ph-question" aria-hidden="true"></i></span><span class="move-right" style="font-size: 150%;"> <i class="ph-fill ph-warning impact-icon impact-icon-major" aria-hidden="true"></i> </span><div> <small> Degraded (Major impact) </small> </div> </article>`,
+					`<article> <a href="https://monkeyisland.slok.dev/system/test3" class="system-link">Test 3</a> <span data-tooltip="Something test 3"><i class="ph-thin ph-question" aria-hidden="true"></i></span><span class="move-right" style="font-size: 150%;"> <i class="ph-fill ph-check-circle impact-icon impact-icon-ok" aria-hidden="true"></i> </span><div> <small> Normal </small> </div> </article>`,
+				},
+			},
+		},
+
+		"Open incidents without impact should be shown as informational with the systems operational.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{
+					Name: "MonkeyIsland",
+					URL:  "https://monkeyisland.slok.dev",
+				},
+				Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone, OpenIRIDs: []string{"ir42"}},
+				OpenedIRs: []*model.IncidentReport{
+					{
+						ID:        "ir42",
+						Name:      "Scheduled maintenance",
+						SystemIDs: []string{"test1"},
+						Start:     t0,
+						Impact:    model.IncidentImpactNone,
+						Timeline: []model.IncidentReportEvent{
+							{Description: "Upgrading the database", TS: t0.Add(11 * time.Minute)},
+						},
+					},
+				},
+				SystemDetails: []model.SystemDetails{
+					{
+						System: model.System{ID: "test1", Name: "Test 1", Description: "Something test 1"},
+						Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone, OpenIRIDs: []string{"ir42"}},
+					},
+				},
+			},
+			expectHTML: map[string][]string{
+				"./index.html": {
+					`<meta name="theme-color" content="#1A7F37" />`,
+					`<strong>All systems operational</strong>`,
+
+					// Ongoing incident info.
+					`<article class="box-impact-none"> <header class="header-impact-none">`,
+					`<h4><a href="https://monkeyisland.slok.dev/ir/ir42" class="incident-title"> Scheduled maintenance</a></h4>`,
+					`<small><i class="ph-fill ph-info impact-icon impact-icon-none" aria-hidden="true"></i> No impact</small>`,
+					`<p>Upgrading the database</p>`,
 
 					// Systems status.
-					`<article> Test 1 <span data-tooltip="Something test 1"><i class="ph-thin ph-question"></i></span><span class="move-right"> <i style="font-size: 150%;" class="ph-fill ph-check-circle text-ok"></i> </span><div> <small> Normal </small> </div> </article>`,
-					`<article> Test 2 <span data-tooltip="Something test 2"><i class="ph-thin ph-question"></i></span><span class="move-right"> <i style="font-size: 150%;" class="ph-fill ph-check-circle text-ok"></i> </span><div> <small> Normal </small> </div> </article>`,
-					`<article> Test 3 <span data-tooltip="Something test 3"><i class="ph-thin ph-question"></i></span><span class="move-right"> <i style="font-size: 150%;" class="ph-fill ph-warning-circle text-"></i> </span><div> <small> Degraded </small> </div> </article>`,
+					`<article> <a href="https://monkeyisland.slok.dev/system/test1" class="system-link">Test 1</a> <span data-tooltip="Something test 1"><i class="ph-thin ph-question" aria-hidden="true"></i></span><span class="move-right" style="font-size: 150%;"> <i class="ph-fill ph-check-circle impact-icon impact-icon-ok" aria-hidden="true"></i> </span><div> <small> Normal </small> </div> </article>`,
+				},
+			},
+		},
+
+		"The auto color scheme should follow the user preferences.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{
+					Name:  "MonkeyIsland",
+					URL:   "https://monkeyisland.slok.dev",
+					Theme: model.Theme{Simple: &model.ThemeSimple{ColorScheme: model.ColorSchemeAuto}},
+				},
+			},
+			expectHTML: map[string][]string{
+				"./index.html": {
+					`<html lang="en"><head>`,
+					`<meta name="color-scheme" content="light dark" />`,
+				},
+			},
+		},
+
+		"A fixed color scheme with high contrast should be set on the pages.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{
+					Name:  "MonkeyIsland",
+					URL:   "https://monkeyisland.slok.dev",
+					Theme: model.Theme{Simple: &model.ThemeSimple{ColorScheme: model.ColorSchemeDark, HighContrast: true}},
+				},
+				History: []*model.IncidentReport{
+					{ID: "ir-1", Name: "Incident report 1", Start: t0, End: t0.Add(time.Hour), Impact: model.IncidentImpactMinor},
+				},
+			},
+			expectHTML: map[string][]string{
+				"./index.html": {
+					`<html lang="en" data-theme="dark" data-contrast="high"><head>`,
+					`<meta name="color-scheme" content="dark" />`,
+				},
+				"./ir/ir-1.html": {
+					`<html lang="en" data-theme="dark" data-contrast="high"><head>`,
+				},
+			},
+		},
+
+		"Draft incidents should be rendered with a draft banner.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{
+					Name: "MonkeyIsland",
+					URL:  "https://monkeyisland.slok.dev",
+				},
+				History: []*model.IncidentReport{
+					{ID: "ir-1", Name: "Incident report 1", Start: t0, End: t0.Add(time.Hour), Impact: model.IncidentImpactMinor, Visibility: model.IncidentVisibilityDraft},
+				},
+			},
+			expectHTML: map[string][]string{
+				"./ir/ir-1.html": {
+					`<article class="draft-banner" role="alert"> <i class="ph-bold ph-pencil-simple" aria-hidden="true"></i> <strong>Draft</strong> This incident is a draft, it is only shown while previewing and it will not be published. </article>`,
+				},
+				"./history/0.html": {
+					`<mark class="draft">Draft</mark>`,
+				},
+			},
+		},
+
+		"Private notes should be rendered on the incident updates.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{
+					Name: "MonkeyIsland",
+					URL:  "https://monkeyisland.slok.dev",
+				},
+				History: []*model.IncidentReport{
+					{ID: "ir-1", Name: "Incident report 1", Start: t0, Impact: model.IncidentImpactMinor, Timeline: []model.IncidentReportEvent{
+						{Description: "Looking", Kind: model.IncidentUpdateKindInvestigating, TS: t0, PrivateNote: "It's the **DB**"},
+					}},
+				},
+			},
+			expectHTML: map[string][]string{
+				"./ir/ir-1.html": {
+					`<p>Looking</p><aside class="private-note"> <small><i class="ph-bold ph-lock-simple" aria-hidden="true"></i> <strong>Private note</strong></small> <p>It&#39;s the <strong>DB</strong></p></aside>`,
+				},
+			},
+		},
+
+		"Postmortems should be rendered on the incident with a table of contents and their assets.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{
+					Name: "MonkeyIsland",
+					URL:  "https://monkeyisland.slok.dev",
+				},
+				History: []*model.IncidentReport{
+					{ID: "ir-1", Name: "Incident report 1", Start: t0, End: t0.Add(time.Hour), Impact: model.IncidentImpactMajor,
+						Timeline: []model.IncidentReportEvent{
+							{Description: "Fixed", Kind: model.IncidentUpdateKindResolved, TS: t0.Add(time.Hour)},
+						},
+						Postmortem: &model.Postmortem{
+							Content: "## Root cause\n\n![Latency](./img/latency.png)\n\n### The `db` migration\n\n```sql\nSELECT 1;\n```",
+							TS:      t0.Add(24 * time.Hour),
+							Assets: map[string]model.Asset{
+								"./img/latency.png": {Path: "2024/img/latency.png", Data: []byte("png")},
+							},
+						},
+					},
+				},
+			},
+			expectHTML: map[string][]string{
+				"./ir/ir-1.html": {
+					`<a href="#postmortem" class="postmortem-link"><i class="ph-bold ph-file-text" aria-hidden="true"></i> Postmortem published</a></p>`,
+					`<section id="postmortem" class="postmortem"> <h2><i class="ph-bold ph-file-text" aria-hidden="true"></i> Postmortem</h2>`,
+					`<aside class="postmortem-toc" aria-label="Contents"> <strong>Contents</strong> <ul> <li class="postmortem-toc-level-2"><a href="#root-cause">Root cause</a></li> <li class="postmortem-toc-level-3"><a href="#the-db-migration">The db migration</a></li> </ul> </aside>`,
+					`<h2 id="root-cause">Root cause</h2> <p><img src="https://monkeyisland.slok.dev/assets/8f8cbb7dcf46e0bc/latency.png" alt="Latency"></p>`,
+					`<pre><code class="language-sql">SELECT 1;`,
+				},
+				"./history/0.html": {
+					`<mark class="postmortem"><i class="ph-bold ph-file-text" aria-hidden="true"></i> Postmortem published</mark>`,
+				},
+				"./assets/8f8cbb7dcf46e0bc/latency.png": {"png"},
+			},
+		},
+
+		"Postmortems table of contents should have the headings rendered text.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{
+					Name: "MonkeyIsland",
+					URL:  "https://monkeyisland.slok.dev",
+				},
+				History: []*model.IncidentReport{
+					{ID: "ir-1", Name: "Incident report 1", Start: t0, End: t0.Add(time.Hour), Impact: model.IncidentImpactMajor,
+						Timeline: []model.IncidentReportEvent{
+							{Description: "Fixed", Kind: model.IncidentUpdateKindResolved, TS: t0.Add(time.Hour)},
+						},
+						Postmortem: &model.Postmortem{
+							Content: "## Latency \\<100ms &amp; \\*spikes\\* on `a\\*b`\n\nText.",
+							TS:      t0.Add(24 * time.Hour),
+						},
+					},
+				},
+			},
+			expectHTML: map[string][]string{
+				"./ir/ir-1.html": {
+					`">Latency &lt;100ms &amp; *spikes* on a\*b</a></li>`,
+				},
+			},
+			expNotContains: map[string][]string{
+				"./ir/ir-1.html": {
+					`\&lt;`,
+					`&amp;amp;`,
+				},
+			},
+		},
+
+		"Relative references on the incident updates should be rewritten to their hashed assets.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{
+					Name: "MonkeyIsland",
+					URL:  "https://monkeyisland.slok.dev",
+				},
+				History: []*model.IncidentReport{
+					{ID: "ir-1", Name: "Incident report 1", Start: t0, End: t0.Add(time.Hour), Impact: model.IncidentImpactMajor,
+						Timeline: []model.IncidentReportEvent{
+							{
+								Description: "See ![Graph](./img/graph.png) and [logs](../logs.txt#L2)",
+								Kind:        model.IncidentUpdateKindResolved,
+								TS:          t0.Add(time.Hour),
+								Assets: map[string]model.Asset{
+									"./img/graph.png": {Path: "2024/img/graph.png", Data: []byte("png")},
+									"../logs.txt#L2":  {Path: "logs.txt", Data: []byte("logs")},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectHTML: map[string][]string{
+				"./ir/ir-1.html": {
+					`<p>See <img src="https://monkeyisland.slok.dev/assets/8f8cbb7dcf46e0bc/graph.png" alt="Graph"> and <a href="https://monkeyisland.slok.dev/assets/98f38f12db221a8c/logs.txt#L2">logs</a></p>`,
+				},
+				"./assets/8f8cbb7dcf46e0bc/graph.png": {"png"},
+				"./assets/98f38f12db221a8c/logs.txt":  {"logs"},
+			},
+		},
+
+		"Relative references on the incident updates raw HTML should be rewritten to their hashed assets.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{
+					Name:     "MonkeyIsland",
+					URL:      "https://monkeyisland.slok.dev",
+					Markdown: model.MarkdownSettings{RawHTML: true},
+				},
+				History: []*model.IncidentReport{
+					{ID: "ir-1", Name: "Incident report 1", Start: t0, End: t0.Add(time.Hour), Impact: model.IncidentImpactMajor,
+						Timeline: []model.IncidentReportEvent{
+							{
+								Description: "Latency <img src=\"./img/graph.png\" width=\"100\"> graph.\n\n<p><a href=\"../logs.txt\">logs</a> <a href=\"./missing.txt\">missing</a></p>",
+								Kind:        model.IncidentUpdateKindResolved,
+								TS:          t0.Add(time.Hour),
+								Assets: map[string]model.Asset{
+									"./img/graph.png": {Path: "2024/img/graph.png", Data: []byte("png")},
+									"../logs.txt":     {Path: "logs.txt", Data: []byte("logs")},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectHTML: map[string][]string{
+				"./ir/ir-1.html": {
+					`<p>Latency <img src="https://monkeyisland.slok.dev/assets/8f8cbb7dcf46e0bc/graph.png" width="100"> graph.</p>`,
+					`<p><a href="https://monkeyisland.slok.dev/assets/98f38f12db221a8c/logs.txt">logs</a> <a href="./missing.txt">missing</a></p>`,
+				},
+				"./assets/8f8cbb7dcf46e0bc/graph.png": {"png"},
+				"./assets/98f38f12db221a8c/logs.txt":  {"logs"},
+			},
+		},
+
+		"Markdown should be rendered with the GFM extensions and sanitized by default.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{
+					Name: "MonkeyIsland",
+					URL:  "https://monkeyisland.slok.dev",
+				},
+				History: []*model.IncidentReport{
+					{ID: "ir-1", Name: "Incident report 1", Start: t0, Impact: model.IncidentImpactMinor, Timeline: []model.IncidentReportEvent{
+						{
+							Description: "| a | b |\n|-|-|\n| 1 | 2 |\n\n~~old~~ https://example.com [home](https://monkeyisland.slok.dev/about)\n\n- [x] done\n\n<script>alert(1)</script>",
+							Kind:        model.IncidentUpdateKindInvestigating,
+							TS:          t0,
+							PrivateNote: "See[^1]\n\n[^1]: The ticket.",
+						},
+					}},
+				},
+			},
+			expectHTML: map[string][]string{
+				"./ir/ir-1.html": {
+					`<table> <thead> <tr> <th>a</th> <th>b</th> </tr> </thead> <tbody> <tr> <td>1</td> <td>2</td> </tr> </tbody> </table>`,
+					`<p><del>old</del> <a href="https://example.com" rel="noopener noreferrer">https://example.com</a> <a href="https://monkeyisland.slok.dev/about">home</a></p>`,
+					`<li><input checked="" disabled="" type="checkbox"> done</li>`,
+					`<p>See<sup id="fnref:1"><a href="#fn:1" class="footnote-ref" role="doc-noteref">1</a></sup></p> <div class="footnotes" role="doc-endnotes">`,
+				},
+			},
+			expNotContains: map[string][]string{
+				"./ir/ir-1.html": {
+					`alert(1)`,
+				},
+			},
+		},
+
+		"Markdown should be rendered with the configured raw HTML, heading anchors, highlighting and external links.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{
+					Name: "MonkeyIsland",
+					URL:  "https://monkeyisland.slok.dev",
+					Markdown: model.MarkdownSettings{
+						DisableStrikethrough: true,
+						RawHTML:              true,
+						HeadingAnchors:       true,
+						CodeHighlightStyle:   "github",
+						ExternalLinkRel:      "nofollow",
+						ExternalLinkTarget:   "_blank",
+					},
+				},
+				History: []*model.IncidentReport{
+					{ID: "ir-1", Name: "Incident report 1", Start: t0, Impact: model.IncidentImpactMinor, Timeline: []model.IncidentReportEvent{
+						{
+							Description: "## Impact\n\n~~old~~ [ticket](https://tickets.slok.dev/1) <b onclick=\"steal()\">bold</b><iframe src=\"https://evil.slok.dev\"></iframe>\n\n```go\nfunc main() {}\n```",
+							Kind:        model.IncidentUpdateKindInvestigating,
+							TS:          t0,
+						},
+					}},
+				},
+			},
+			expectHTML: map[string][]string{
+				"./ir/ir-1.html": {
+					`<h2 id="impact">Impact<a href="#impact" class="heading-anchor">#</a></h2>`,
+					`<p>~~old~~ <a href="https://tickets.slok.dev/1" rel="nofollow noopener" target="_blank">ticket</a> <b>bold</b></p>`,
+					`<span style="color: #000; font-weight: bold">func</span>`,
+				},
+			},
+			expNotContains: map[string][]string{
+				"./ir/ir-1.html": {
+					`steal()`,
+					`evil.slok.dev`,
+				},
+			},
+		},
+
+		"Plain text fields should be escaped.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{
+					Name: "Monkey<script>alert(0)</script>Island",
+					URL:  "https://monkeyisland.slok.dev",
+				},
+				SystemDetails: []model.SystemDetails{
+					{
+						System: model.System{ID: "test1", Name: "Test <script>alert(1)</script>", Description: `Something "<script>alert(2)</script>`},
+						Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
+						IRs: []*model.IncidentReport{
+							{ID: "ir-1", Name: "Shared outage <script>alert(3)</script>", SystemIDs: []string{"test1"}, Start: t0, Impact: model.IncidentImpactMinor, Timeline: []model.IncidentReportEvent{
+								{Description: "Looking", Kind: model.IncidentUpdateKindInvestigating, TS: t0},
+							}},
+						},
+					},
+				},
+				OpenedIRs: []*model.IncidentReport{
+					{ID: "ir-1", Name: "Shared outage <script>alert(3)</script>", SystemIDs: []string{"test1"}, Start: t0, Impact: model.IncidentImpactMinor, Timeline: []model.IncidentReportEvent{
+						{Description: "Looking", Kind: model.IncidentUpdateKindInvestigating, TS: t0},
+					}},
+				},
+				History: []*model.IncidentReport{
+					{ID: "ir-1", Name: "Shared outage <script>alert(3)</script>", SystemIDs: []string{"test1"}, Start: t0, Impact: model.IncidentImpactMinor, Timeline: []model.IncidentReportEvent{
+						{Description: "Looking", Kind: model.IncidentUpdateKindInvestigating, TS: t0},
+					}},
+				},
+			},
+			expectHTML: map[string][]string{
+				"./index.html": {
+					`<title>Monkey&lt;script&gt;alert(0)&lt;/script&gt;Island status</title>`,
+					`<h4><a href="https://monkeyisland.slok.dev/ir/ir-1" class="incident-title"> Shared outage &lt;script&gt;alert(3)&lt;/script&gt;</a></h4>`,
+					`<a href="https://monkeyisland.slok.dev/system/test1" class="system-link">Test &lt;script&gt;alert(1)&lt;/script&gt;</a> <span data-tooltip="Something &#34;&lt;script&gt;alert(2)&lt;/script&gt;">`,
+				},
+				"./ir/ir-1.html": {
+					`<title>Shared outage &lt;script&gt;alert(3)&lt;/script&gt; - Monkey&lt;script&gt;alert(0)&lt;/script&gt;Island status</title>`,
+					`<h1 style="text-align: center;" class="text-minor">Shared outage &lt;script&gt;alert(3)&lt;/script&gt;</h1>`,
+				},
+				"./history/0.html": {
+					`Shared outage &lt;script&gt;alert(3)&lt;/script&gt;</a></h4>`,
+				},
+				"./system/test1/index.html": {
+					`<h1>Test &lt;script&gt;alert(1)&lt;/script&gt;</h1>`,
+					`Shared outage &lt;script&gt;alert(3)&lt;/script&gt;</a></h4>`,
 				},
 			},
+			expNotContains: map[string][]string{
+				"./index.html":              {`<script>alert(`},
+				"./ir/ir-1.html":            {`<script>alert(`},
+				"./history/0.html":          {`<script>alert(`},
+				"./system/test1/index.html": {`<script>alert(`},
+			},
 		},
 
 		"History pagination should be rendered correctly.": {
@@ -174,7 +612,7 @@ func TestCreateUI(t *testing.T) {
 					URL:  "https://monkeyisland.slok.dev",
 				},
 				SystemDetails: []model.SystemDetails{
-					{System: model.System{ID: "test1", Name: "Test 1", Description: "Something test 1"}},
+					{System: model.System{ID: "test1", Name: "Test 1", Description: "Something test 1"}, Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone}},
 				},
 				History: []*model.IncidentReport{
 					{
@@ -224,14 +662,14 @@ func TestCreateUI(t *testing.T) {
 					// Incident 1.
 					`<h4><a href="https://monkeyisland.slok.dev/ir/ir-1" class="incident-title-major"> Incident report 1</a>`, // We have the title with impact an details URL.
 					`<p>Some detail 11</p>`, // We have plain text details.
-					`<span x-init="renderTSUnixPrettyNoYear($el)">-1815346677</span> - <span x-init="renderTSUnixPrettyNoYear($el)">-1815339477</span>`, // Start and end TS.
-					`<mark class="resolved">Resolved</mark>`, // Resolved mark.
+					`<span x-init="renderTSUnixPrettyNoYear($el)">-1815346677</span> - <span x-init="renderTSUnixPrettyNoYear($el)">-1815339477</span>`,       // Start and end TS.
+					`<mark class="resolved">Resolved</mark> <i class="ph-fill ph-warning impact-icon impact-icon-major" aria-hidden="true"></i> Major impact`, // Resolved mark and impact.
 
 					// Incident 2.
 					`<h4><a href="https://monkeyisland.slok.dev/ir/ir-2" class="incident-title-critical"> Incident report 2</a></h4>`,
 					`<p>Some <strong>detail</strong> 12</p>`,                          // We have markdown details.
 					`<span x-init="renderTSUnixPrettyNoYear($el)">-1815310677</span>`, // Start.
-					`<mark class="unresolved">Ongoing</mark>`,                         // Unresolved mark.
+					`<mark class="unresolved">Ongoing</mark> <i class="ph-fill ph-x-circle impact-icon impact-icon-critical" aria-hidden="true"></i> Critical impact`, // Unresolved mark and impact.
 
 					// Pagination.
 					`<a href="https://monkeyisland.slok.dev/history/1" role="button"> ⮜ Previous </a>`,
@@ -255,6 +693,324 @@ func TestCreateUI(t *testing.T) {
 			},
 		},
 
+		"History archive by month should be rendered correctly.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{
+					Name: "MonkeyIsland",
+					URL:  "https://monkeyisland.slok.dev",
+				},
+				History: []*model.IncidentReport{
+					{
+						ID:     "ir-3",
+						Name:   "Incident report 3",
+						Start:  t0.AddDate(0, 1, 0),
+						End:    t0.AddDate(0, 1, 0).Add(time.Hour),
+						Impact: model.IncidentImpactMinor,
+					},
+					{
+						ID:     "ir-2",
+						Name:   "Incident report 2",
+						Start:  t0.Add(2 * time.Hour),
+						End:    t0.Add(3 * time.Hour),
+						Impact: model.IncidentImpactCritical,
+					},
+					{
+						ID:     "ir-1",
+						Name:   "Incident report 1",
+						Start:  t0,
+						End:    t0.Add(time.Hour),
+						Impact: model.IncidentImpactMajor,
+					},
+				},
+			},
+			expectHTML: map[string][]string{
+				"./history/0.html": {
+					`<h3>Archive</h3>`,
+					`<strong>1912</strong> <a href="https://monkeyisland.slok.dev/history/1912/07" class="history-archive-month text-minor"><i class="ph-fill ph-warning-circle impact-icon impact-icon-minor" aria-hidden="true"></i> Jul (1)<span class="visually-hidden">, Minor impact</span></a> <a href="https://monkeyisland.slok.dev/history/1912/06" class="history-archive-month text-critical"><i class="ph-fill ph-x-circle impact-icon impact-icon-critical" aria-hidden="true"></i> Jun (2)<span class="visually-hidden">, Critical impact</span></a>`,
+				},
+
+				"./history/1912/06.html": {
+					`<h1>Incident History: June 1912</h1>`,
+
+					// Calendar (June 1912 starts on Saturday).
+					`<tr> <td></td> <td></td> <td></td> <td></td> <td></td> <td class="calendar-day calendar-day-ok" aria-label="1912-06-01: 0 incidents, Operational" data-tooltip="1912-06-01: 0 incidents, Operational">1</td>`,
+					`<td class="calendar-day calendar-day-critical" aria-label="1912-06-23: 2 incidents, Critical impact" data-tooltip="1912-06-23: 2 incidents, Critical impact">23</td>`,
+					`<td class="calendar-day calendar-day-ok" aria-label="1912-06-30: 0 incidents, Operational" data-tooltip="1912-06-30: 0 incidents, Operational">30</td> </tr>`,
+
+					// Incidents.
+					`<h4><a href="https://monkeyisland.slok.dev/ir/ir-2" class="incident-title-critical"> Incident report 2</a></h4>`,
+					`<h4><a href="https://monkeyisland.slok.dev/ir/ir-1" class="incident-title-major"> Incident report 1</a></h4>`,
+
+					// Pagination.
+					`<a href="https://monkeyisland.slok.dev/history/1912/07" role="button"> Next month ⮞ </a>`,
+				},
+
+				"./history/1912/07.html": {
+					`<h1>Incident History: July 1912</h1>`,
+					`<td class="calendar-day calendar-day-minor" aria-label="1912-07-23: 1 incidents, Minor impact" data-tooltip="1912-07-23: 1 incidents, Minor impact">23</td>`,
+					`<h4><a href="https://monkeyisland.slok.dev/ir/ir-3" class="incident-title-minor"> Incident report 3</a></h4>`,
+					`<a href="https://monkeyisland.slok.dev/history/1912/06" role="button"> ⮜ Previous month </a>`,
+				},
+			},
+		},
+
+		"Search page and its sharded index should be rendered correctly.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{
+					Name: "MonkeyIsland",
+					URL:  "https://monkeyisland.slok.dev",
+				},
+				SystemDetails: []model.SystemDetails{
+					{System: model.System{ID: "test1", Name: "Test 1"}, Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone}},
+				},
+				History: []*model.IncidentReport{
+					{
+						ID:        "ir-3",
+						Name:      "Incident report 3",
+						SystemIDs: []string{"test1"},
+						Start:     t0.Add(2 * time.Hour),
+						Impact:    model.IncidentImpactCritical,
+						Timeline: []model.IncidentReportEvent{
+							{Description: "Webhooks **timeout**\n\n- [Retrying](https://monkeyisland.slok.dev)"},
+							{Description: "Investigating"},
+						},
+					},
+					{
+						ID:     "ir-2",
+						Name:   "Incident report 2",
+						Start:  t0.Add(time.Hour),
+						End:    t0.Add(2 * time.Hour),
+						Impact: model.IncidentImpactMinor,
+					},
+					{
+						ID:     "ir-1",
+						Name:   "Incident report 1",
+						Start:  t0,
+						End:    t0.Add(time.Hour),
+						Impact: model.IncidentImpactMajor,
+					},
+				},
+			},
+			expectHTML: map[string][]string{
+				"./index.html": {
+					`<li><a href="https://monkeyisland.slok.dev/search">Search</a></li>`,
+				},
+				"./history/0.html": {
+					`<form action="https://monkeyisland.slok.dev/search" method="get" role="search">`,
+				},
+				"./search.html": {
+					`<h1>Search incidents</h1>`,
+					`data-index-url="https://monkeyisland.slok.dev/search/index.json"`,
+				},
+				"./search/index.json": {
+					`{"version":1,"total":3,"irURL":"https://monkeyisland.slok.dev/ir/{id}","systems":{"test1":"Test 1"},"shards":["https://monkeyisland.slok.dev/search/shard-0.json","https://monkeyisland.slok.dev/search/shard-1.json"]}`,
+				},
+				"./search/shard-0.json": {
+					`[{"i":"ir-3","n":"Incident report 3","s":["test1"],"m":"critical","st":-1815339477,"t":"Webhooks timeout Retrying Investigating"},{"i":"ir-2","n":"Incident report 2","m":"minor","st":-1815343077,"en":-1815339477}]`,
+				},
+				"./search/shard-1.json": {
+					`[{"i":"ir-1","n":"Incident report 1","m":"major","st":-1815346677,"en":-1815343077}]`,
+				},
+			},
+		},
+
+		"SEO metadata, sitemap and robots should be rendered correctly.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{
+					Name: "MonkeyIsland",
+					URL:  "https://monkeyisland.slok.dev",
+				},
+				SystemDetails: []model.SystemDetails{
+					{
+						System: model.System{ID: "test1", Name: "Test 1", Description: "Something test 1"},
+						Status: model.SystemStatus{Impact: model.IncidentImpactMajor, OpenIRIDs: []string{"ir-1"}},
+						IRs:    []*model.IncidentReport{{ID: "ir-1", Name: "Incident \"report\" 1", SystemIDs: []string{"test1"}, Start: t0, Impact: model.IncidentImpactMajor}},
+					},
+				},
+				OpenedIRs: []*model.IncidentReport{{ID: "ir-1", Name: "Incident \"report\" 1", SystemIDs: []string{"test1"}, Start: t0, Impact: model.IncidentImpactMajor, Timeline: []model.IncidentReportEvent{{TS: t0.Add(time.Hour), Description: "Some detail 11"}}}},
+				History: []*model.IncidentReport{
+					{
+						ID:        "ir-1",
+						Name:      "Incident \"report\" 1",
+						SystemIDs: []string{"test1"},
+						Start:     t0,
+						Impact:    model.IncidentImpactMajor,
+						Timeline: []model.IncidentReportEvent{
+							{TS: t0.Add(time.Hour), Description: "Some detail 11"},
+						},
+					},
+				},
+				Pages: []model.Page{{Title: "About", Slug: "about", Content: "About us."}},
+			},
+			expectHTML: map[string][]string{
+				"./index.html": {
+					`<title>MonkeyIsland status</title>`,
+					`<meta name="description" content="MonkeyIsland status: 1 ongoing incidents (Incident &#34;report&#34; 1)." />`,
+					`<link rel="canonical" href="https://monkeyisland.slok.dev/" />`,
+					`<meta property="og:url" content="https://monkeyisland.slok.dev/" />`,
+					`<meta property="og:title" content="MonkeyIsland status" />`,
+					`<meta property="og:type" content="website" />`,
+					`<meta property="og:image" content="https://monkeyisland.slok.dev/og/index.png" />`,
+					`<meta name="twitter:card" content="summary_large_image" />`,
+				},
+				"./ir/ir-1.html": {
+					`<title>Incident &#34;report&#34; 1 - MonkeyIsland status</title>`,
+					`<meta name="description" content="Ongoing major impact incident affecting Test 1, started on 1912-06-23 01:02 UTC." />`,
+					`<link rel="canonical" href="https://monkeyisland.slok.dev/ir/ir-1" />`,
+					`<meta property="og:title" content="Incident &#34;report&#34; 1" />`,
+					`<meta property="og:type" content="article" />`,
+					`<meta property="og:image" content="https://monkeyisland.slok.dev/og/ir/ir-1.png" />`,
+				},
+				"./system/test1/index.html": {
+					`<meta name="description" content="Test 1 is degraded (major impact). Something test 1" />`,
+					`<link rel="canonical" href="https://monkeyisland.slok.dev/system/test1" />`,
+				},
+				"./robots.txt": {
+					`User-agent: * Allow: /Sitemap: https://monkeyisland.slok.dev/sitemap.xml`,
+				},
+				"./sitemap.xml": {
+					`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`,
+					`<url> <loc>https://monkeyisland.slok.dev/</loc> </url>`,
+					`<url> <loc>https://monkeyisland.slok.dev/history/0</loc> </url>`,
+					`<url> <loc>https://monkeyisland.slok.dev/history/1912/06</loc> </url>`,
+					`<url> <loc>https://monkeyisland.slok.dev/search</loc> </url>`,
+					`<url> <loc>https://monkeyisland.slok.dev/ir/ir-1</loc> <lastmod>1912-06-23T02:02:03Z</lastmod> </url>`,
+					`<url> <loc>https://monkeyisland.slok.dev/system/test1</loc> </url>`,
+					`<url> <loc>https://monkeyisland.slok.dev/page/about</loc> </url>`,
+				},
+			},
+		},
+
+		"Status pages with no index should not be indexed by search engines.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{
+					Name: "MonkeyIsland",
+					URL:  "https://monkeyisland.slok.dev",
+					SEO:  model.SEOSettings{NoIndex: true, Description: "Internal status page."},
+				},
+			},
+			expectHTML: map[string][]string{
+				"./index.html": {
+					`<meta name="description" content="Internal status page." />`,
+					`<meta name="robots" content="noindex, nofollow" />`,
+				},
+				"./robots.txt": {
+					`User-agent: * Disallow: /`,
+				},
+			},
+			expNotExist: []string{"./sitemap.xml"},
+		},
+
+		"A custom robots file should be used if set.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{
+					Name: "MonkeyIsland",
+					URL:  "https://monkeyisland.slok.dev",
+					SEO:  model.SEOSettings{Robots: "User-agent: *\nDisallow: /search\n"},
+				},
+			},
+			expectHTML: map[string][]string{
+				"./robots.txt": {
+					`User-agent: * Disallow: /search`,
+				},
+				"./sitemap.xml": {
+					`<url> <loc>https://monkeyisland.slok.dev/</loc> </url>`,
+				},
+			},
+		},
+
+		"Multiple languages should render a localized site per language with a language switcher.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{
+					Name:      "MonkeyIsland",
+					URL:       "https://monkeyisland.slok.dev",
+					Languages: []string{"en", "es"},
+				},
+				Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
+				History: []*model.IncidentReport{
+					{
+						ID:             "ir-1",
+						Name:           "Incident report 1",
+						LocalizedNames: map[string]string{"es": "Incidente 1"},
+						Start:          t0,
+						End:            t0.Add(2 * time.Hour),
+						Impact:         model.IncidentImpactMajor,
+						Timeline: []model.IncidentReportEvent{
+							{TS: t0.Add(5 * time.Minute), Kind: model.IncidentUpdateKindResolved, Description: "Fixed", LocalizedDescriptions: map[string]string{"es": "Arreglado"}},
+							{TS: t0.Add(2 * time.Minute), Kind: model.IncidentUpdateKindInvestigating, Description: "Looking"},
+						},
+					},
+				},
+			},
+			expectHTML: map[string][]string{
+				"./index.html": {
+					`<html lang="en">`,
+					`<link rel="alternate" hreflang="en" href="https://monkeyisland.slok.dev/" />`,
+					`<link rel="alternate" hreflang="es" href="https://monkeyisland.slok.dev/es/" />`,
+					`<strong>All systems operational</strong>`,
+					`<summary aria-label="Language"><i class="ph ph-translate"></i> English</summary>`,
+					`<li><a href="https://monkeyisland.slok.dev/" hreflang="en" lang="en" aria-current="page">English</a></li>`,
+					`<li><a href="https://monkeyisland.slok.dev/es/" hreflang="es" lang="es">Español</a></li>`,
+				},
+				"./ir/ir-1.html": {
+					`class="text-major">Incident report 1</h1>`,
+					`<blockquote> <h4> Resolved </h4> <p>Fixed</p>`,
+					`<li><a href="https://monkeyisland.slok.dev/es/ir/ir-1" hreflang="es" lang="es">Español</a></li>`,
+				},
+				"es/index.html": {
+					`<html lang="es">`,
+					`<title>Estado de MonkeyIsland</title>`,
+					`<link rel="stylesheet" href="https://monkeyisland.slok.dev/static/main.css" />`,
+					`<script src="https://cdn.jsdelivr.net/npm/dayjs@1/locale/es.js"></script> <script>dayjs.locale("es")</script>`,
+					`<strong>Todos los sistemas operativos</strong>`,
+					`<li><a href="https://monkeyisland.slok.dev/es/history/0">Historial</a></li>`,
+					`<li><a href="https://monkeyisland.slok.dev/" hreflang="en" lang="en">English</a></li>`,
+					`<li><a href="https://monkeyisland.slok.dev/es/" hreflang="es" lang="es" aria-current="page">Español</a></li>`,
+				},
+				"es/ir/ir-1.html": {
+					`class="text-major">Incidente 1</h1>`,
+					`<meta name="description" content="Incidente resuelto de impacto mayor, iniciado el 1912-06-23 01:02 UTC." />`,
+					`<strong>Incidente resuelto en 2 horas</strong>`,
+					`<blockquote> <h4> Resuelto </h4> <p>Arreglado</p>`,
+					`<blockquote> <h4> Investigando </h4> <p>Looking</p>`, // Fallback to the default language.
+					`<meta property="og:image" content="https://monkeyisland.slok.dev/og/ir/ir-1.png" />`,
+				},
+				"es/history/1912/06.html": {
+					`<h1>Historial de incidentes: junio 1912</h1>`,
+					`<th>Lun</th><th>Mar</th>`,
+				},
+				"./sitemap.xml": {
+					`<url> <loc>https://monkeyisland.slok.dev/</loc> </url>`,
+					`<url> <loc>https://monkeyisland.slok.dev/es/</loc> </url>`,
+					`<url> <loc>https://monkeyisland.slok.dev/es/ir/ir-1</loc> <lastmod>1912-06-23T03:02:03Z</lastmod> </url>`,
+				},
+			},
+			expNotExist: []string{"es/static/main.css", "es/sitemap.xml"},
+		},
+
+		"Custom translations should override the theme messages and fall back to the default language.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{
+					Name:      "MonkeyIsland",
+					URL:       "https://monkeyisland.slok.dev",
+					Languages: []string{"ca"},
+				},
+				Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
+			},
+			messages: common.Messages{
+				"en": {"status_all_operational": "Everything is fine"},
+				"ca": {"language_name": "Català", "nav_history": "Historial"},
+			},
+			expectHTML: map[string][]string{
+				"./index.html": {
+					`<html lang="ca">`,
+					`<strong>Everything is fine</strong>`,
+					`<li><a href="https://monkeyisland.slok.dev/history/0">Historial</a></li>`,
+					`<li><a href="https://monkeyisland.slok.dev/search">Search</a></li>`,
+				},
+			},
+		},
+
 		"IR details should be rendered correctly.": {
 			ui: model.UI{
 				Settings: model.StatusPageSettings{
@@ -293,9 +1049,10 @@ func TestCreateUI(t *testing.T) {
 					`<nav`, // We have the nav var.
 					`Powered by <a href="https://github.com/slok/stactus">Stactus</a>.`, // We have the footer.
 
-					`class="text-major">Incident report 1</h1>`,    // We have the IR title with impact.
-					`<article class="incident-resolved">`,          // Resolved mark.
-					`<strong>Incident resolved in 2h0m0s</strong>`, // We have the time took to be resolved.
+					`class="text-major">Incident report 1</h1>`,     // We have the IR title with impact.
+					`<article class="incident-resolved">`,           // Resolved mark.
+					`<strong>Incident resolved in 2 hours</strong>`, // We have the time took to be resolved.
+					`<mark class="impact-label impact-label-major"><i class="ph-fill ph-warning impact-icon impact-icon-major" aria-hidden="true"></i> Major impact</mark>`, // We have the impact label.
 
 					// Timeline.
 					`<blockquote> <h4> Resolved </h4> <p>Some detail 13</p><footer> <cite x-init="renderTSUnixPrettyNoYear($el)">-1815346377</cite> </footer> </blockquote>`,
@@ -307,15 +1064,148 @@ func TestCreateUI(t *testing.T) {
 					`<nav`, // We have the nav var.
 					`Powered by <a href="https://github.com/slok/stactus">Stactus</a>.`, // We have the footer.
 
-					`class="text-minor">Incident report 2</h1>`, // We have the IR title with impact.
-					`<article class="incident-ongoing-minor">`,  // Not resolved mark with impact.
-					`<strong>Incident ongoing</strong>`,         // We have the incident ongoing message.
+					`class="text-minor">Incident report 2</h1>`,                           // We have the IR title with impact.
+					`<article class="incident-ongoing-minor">`,                            // Not resolved mark with impact.
+					`<strong>Incident ongoing</strong> <small>Started 2 days ago</small>`, // We have the incident ongoing message.
 
 					// Timeline.
 					`<blockquote> <h4> Investigating </h4> <p>Some detail 23</p><footer> <cite x-init="renderTSUnixPrettyNoYear($el)">-1815345777</cite> </footer> </blockquote>`,
 				},
 			},
 		},
+
+		"Custom pages should be rendered correctly and added to the nav.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{
+					Name: "MonkeyIsland",
+					URL:  "https://monkeyisland.slok.dev",
+				},
+				Pages: []model.Page{
+					{Slug: "support-hours", Title: "Support hours", NavOrder: 1, Content: "From **9** to 5."},
+					{Slug: "about", Title: "About", NavOrder: 2, Content: "Something about us."},
+				},
+			},
+			expectHTML: map[string][]string{
+				"./index.html": {
+					`<li><a href="https://monkeyisland.slok.dev/page/support-hours">Support hours</a></li>`,
+					`<li><a href="https://monkeyisland.slok.dev/page/about">About</a></li>`,
+				},
+
+				"./page/support-hours.html": {
+					`<nav`, // We have the nav var.
+					`Powered by <a href="https://github.com/slok/stactus">Stactus</a>.`, // We have the footer.
+
+					`<h1>Support hours</h1>`,               // We have the title.
+					`<p>From <strong>9</strong> to 5.</p>`, // We have the markdown content.
+				},
+
+				"./page/about.html": {
+					`<h1>About</h1>`,
+					`<p>Something about us.</p>`,
+				},
+			},
+		},
+
+		"System details should be rendered correctly.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{
+					Name: "MonkeyIsland",
+					URL:  "https://monkeyisland.slok.dev",
+				},
+				SystemDetails: []model.SystemDetails{
+					{
+						System: model.System{ID: "test1", Name: "Test 1", Description: "Something test 1"},
+						LatestIR: &model.IncidentReport{
+							ID: "ir-3", Name: "Incident report 3", Start: t0.Add(47 * time.Hour), Impact: model.IncidentImpactMinor,
+						},
+						IRs: []*model.IncidentReport{
+							{ID: "ir-3", Name: "Incident report 3", Start: t0.Add(47 * time.Hour), Impact: model.IncidentImpactMinor},
+							{ID: "ir-2", Name: "Incident report 2", Start: t0.Add(25 * time.Hour), End: t0.Add(26 * time.Hour), Impact: model.IncidentImpactCritical},
+							{ID: "ir-1", Name: "Incident report 1", Start: t0.Add(24 * time.Hour), End: t0.Add(25 * time.Hour), Impact: model.IncidentImpactMajor},
+						},
+						Status: model.SystemStatus{Impact: model.IncidentImpactMinor, OpenIRIDs: []string{"ir-3"}},
+						Stats:  model.SystemStats{TotalIRs: 3, MTTR: 1 * time.Hour},
+					},
+					{
+						System: model.System{ID: "test2", Name: "Test 2"},
+						Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
+					},
+				},
+			},
+			expectHTML: map[string][]string{
+				"./index.html": {
+					`<a href="https://monkeyisland.slok.dev/system/test1" class="system-link">Test 1</a>`,
+					`<a href="https://monkeyisland.slok.dev/system/test2" class="system-link">Test 2</a>`,
+				},
+
+				"./system/test1/index.html": {
+					`<nav`, // We have the nav var.
+					`Powered by <a href="https://github.com/slok/stactus">Stactus</a>.`, // We have the footer.
+
+					`<link rel=alternate title="Test 1 incident history" type=application/atom+xml href="https://monkeyisland.slok.dev/system/test1/history-feed.atom">`, // We have the system feeds.
+					`<link rel=alternate title="Test 1 incident history" type=application/rss+xml href="https://monkeyisland.slok.dev/system/test1/history-feed.rss">`,
+					`<link rel=alternate title="Test 1 incident history" type=application/feed+json href="https://monkeyisland.slok.dev/system/test1/history-feed.json">`,
+					`<h1>Test 1</h1>`,         // We have the system name.
+					`<p>Something test 1</p>`, // We have the system description.
+					`<article class="incident-ongoing-minor"> <i class="ph-bold ph-warning-circle" aria-hidden="true"></i> <strong>Degraded</strong>`, // The current status.
+
+					// Uptime.
+					`<span class="uptime-bar uptime-bar-ok" role="img" aria-label="1912-06-23: 0 incidents, Operational" data-tooltip="1912-06-23: 0 incidents, Operational"></span>`,
+					`<span class="uptime-bar uptime-bar-critical" role="img" aria-label="1912-06-24: 2 incidents, Critical impact" data-tooltip="1912-06-24: 2 incidents, Critical impact"></span>`,
+					`<span class="uptime-bar uptime-bar-minor" role="img" aria-label="1912-06-25: 1 incidents, Minor impact" data-tooltip="1912-06-25: 1 incidents, Minor impact"></span>`,
+					`<small>3 days ago <span class="move-right">93.88% uptime</span></small>`,
+
+					// Stats.
+					`<small>Incidents</small> <h4>3</h4>`,
+					`<small>MTTR</small> <h4>1 hour</h4>`,
+
+					// Incidents.
+					`<h4><a href="https://monkeyisland.slok.dev/ir/ir-3" class="incident-title-minor"> Incident report 3</a></h4>`,
+					`<h4><a href="https://monkeyisland.slok.dev/ir/ir-2" class="incident-title-critical"> Incident report 2</a></h4>`,
+
+					// Pagination.
+					`<a href="https://monkeyisland.slok.dev/system/test1/history/1" role="button"> ⮜ Previous </a>`,
+				},
+
+				"./system/test1/history/1.html": {
+					`<h1>Test 1</h1>`,
+					`<h4><a href="https://monkeyisland.slok.dev/ir/ir-1" class="incident-title-major"> Incident report 1</a></h4>`,
+					`<a href="https://monkeyisland.slok.dev/system/test1" role="button"> Next ⮞ </a>`,
+				},
+
+				"./system/test2/index.html": {
+					`<h1>Test 2</h1>`,
+					`<article class="operational-box"> <i class="ph-bold ph-check" aria-hidden="true"></i> <strong>Operational</strong>`,
+					`<p>No incidents reported.</p>`,
+				},
+			},
+		},
+
+		"System uptime should merge overlapping incidents and ignore the ones without impact.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{
+					Name: "MonkeyIsland",
+					URL:  "https://monkeyisland.slok.dev",
+				},
+				SystemDetails: []model.SystemDetails{
+					{
+						System: model.System{ID: "test1", Name: "Test 1"},
+						IRs: []*model.IncidentReport{
+							{ID: "ir-3", Name: "Incident report 3", Start: t0.Add(30 * time.Hour), End: t0.Add(40 * time.Hour), Impact: model.IncidentImpactNone},
+							{ID: "ir-2", Name: "Incident report 2", Start: t0.Add(25 * time.Hour), End: t0.Add(27 * time.Hour), Impact: model.IncidentImpactMinor},
+							{ID: "ir-1", Name: "Incident report 1", Start: t0.Add(24 * time.Hour), End: t0.Add(26 * time.Hour), Impact: model.IncidentImpactCritical},
+						},
+						Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
+						Stats:  model.SystemStats{TotalIRs: 3, MTTR: 1 * time.Hour},
+					},
+				},
+			},
+			expectHTML: map[string][]string{
+				"./system/test1/index.html": {
+					`<small>3 days ago <span class="move-right">93.88% uptime</span></small>`,
+				},
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -325,9 +1215,13 @@ func TestCreateUI(t *testing.T) {
 
 			fm := utilfs.NewTestFileManager()
 			gen, err := simple.NewGenerator(simple.GeneratorConfig{
-				FileManager:      fm,
-				OutPath:          "./",
-				HistoryIRPerPage: 2,
+				FileManager:       fm,
+				OutPath:           "./",
+				HistoryIRPerPage:  2,
+				SystemUptimeDays:  3,
+				SearchIRsPerShard: 2,
+				Messages:          test.messages,
+				TimeNow:           func() time.Time { return t0.Add(48 * time.Hour) },
 			})
 			require.NoError(err)
 			err = gen.CreateUI(context.TODO(), test.ui)
@@ -338,6 +1232,12 @@ func TestCreateUI(t *testing.T) {
 				for file, exp := range test.expectHTML {
 					fm.AssertContains(t, file, exp)
 				}
+				for file, notExp := range test.expNotContains {
+					fm.AssertNotContains(t, file, notExp)
+				}
+				for _, file := range test.expNotExist {
+					fm.AssertNotExists(t, file)
+				}
 			}
 		})
 	}
@@ -19,4 +19,81 @@ function renderTSUnixPretty(el, format) {
 function renderTSUnixAgo(el) {
   let ts = dayjs.unix(el.innerHTML)
   el.innerHTML = ts.fromNow()
-}
\ No newline at end of file
+}
+// Incident search AlpineJS component, searches on the static search index loading
+// the index shards progressively (latest incidents first, a few in parallel) and caching them,
+// it stops loading shards as soon as it has enough results.
+// Usage example: <main x-data="incidentSearch($el.dataset.indexUrl, $el.dataset.errorMessage)" data-index-url="https://status.example.com/search/index.json" data-error-message="Search failed">
+function incidentSearch(indexURL, errorMessage = "Search failed") {
+  const maxResults = 50
+  const parallelShards = 4
+  let manifest = null
+  const shards = {}
+
+  const normalize = (s) => (s || "").toLowerCase().normalize("NFD").replace(/[\u0300-\u036f]/g, "")
+  const fetchJSON = (url) => fetch(url).then((resp) => {
+    if (!resp.ok) throw new Error(`could not load ${url}: ${resp.status}`)
+    return resp.json()
+  })
+  // Cache the shard promises so the same shard is never requested twice.
+  const loadShard = (url) => {
+    shards[url] = shards[url] || fetchJSON(url).catch((err) => {
+      delete shards[url]
+      throw err
+    })
+    return shards[url]
+  }
+
+  return {
+    query: new URLSearchParams(window.location.search).get("q") || "",
+    results: [],
+    loading: false,
+    searched: false,
+    error: "",
+
+    init() {
+      if (this.query) this.search()
+    },
+
+    async search() {
+      const terms = normalize(this.query).split(/\s+/).filter((t) => t)
+      this.results = []
+      this.error = ""
+      this.searched = terms.length > 0
+      if (!this.searched) return
+
+      // Keep the query on the URL so searches can be shared.
+      const url = new URL(window.location)
+      url.searchParams.set("q", this.query)
+      window.history.replaceState(null, "", url)
+
+      this.loading = true
+      try {
+        manifest = manifest || await fetchJSON(indexURL)
+        for (let i = 0; i < manifest.shards.length; i += parallelShards) {
+          // Fetch a batch of shards in parallel but process them in order to keep the latest incidents first.
+          const batch = await Promise.all(manifest.shards.slice(i, i + parallelShards).map(loadShard))
+          for (const entries of batch) {
+            for (const e of entries) {
+              const systems = (e.s || []).map((id) => manifest.systems[id] || id)
+              const haystack = normalize([e.i, e.n, e.m, systems.join(" "), (e.s || []).join(" "), e.t].join(" "))
+              if (!terms.every((t) => haystack.includes(t))) continue
+
+              this.results.push(Object.assign({
+                url: manifest.irURL.replace("{id}", e.i),
+                systems: systems.join(", "),
+                startPretty: dayjs.unix(e.st).format("MMM DD, YYYY, HH:mm"),
+                endPretty: e.en ? dayjs.unix(e.en).format("MMM DD, YYYY, HH:mm") : "",
+              }, e))
+              if (this.results.length >= maxResults) return
+            }
+          }
+        }
+      } catch (err) {
+        this.error = `${errorMessage}: ${err.message}`
+      } finally {
+        this.loading = false
+      }
+    },
+  }
+}
@@ -30,3 +30,18 @@ func splitYAML(data []byte) []string {
 
 	return nonEmptyData
 }
+
+var frontMatterRe = regexp.MustCompile(`(?s)^---[ \t]*\r?\n(.*?)\r?\n---[ \t]*(?:\r?\n|$)`)
+
+// splitFrontMatter will split the YAML front matter (delimited by `---`) from the
+// rest of the content of a file.
+func splitFrontMatter(data []byte) (frontMatter string, content string, ok bool) {
+	data = bytes.TrimLeft(data, " \t\r\n")
+
+	match := frontMatterRe.FindSubmatchIndex(data)
+	if match == nil {
+		return "", string(data), false
+	}
+
+	return string(data[match[2]:match[3]]), strings.TrimSpace(string(data[match[1]:])), true
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"io/fs"
+	"net/url"
+	"path"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -15,12 +17,19 @@ import (
 	"github.com/slok/stactus/internal/log"
 	"github.com/slok/stactus/internal/model"
 	"github.com/slok/stactus/internal/storage/memory"
+	utilhtml "github.com/slok/stactus/internal/util/html"
 )
 
 type ReadRepositoryConfig struct {
-	IncidentsFS     fs.FS
+	IncidentsFS fs.FS
+	// PagesFS is optional, if set, custom Markdown pages will be loaded from it.
+	PagesFS         fs.FS
 	StactusFileData string
-	Logger          log.Logger
+	// FilterIncidentsBySystem will ignore the incidents that don't affect any of the stactus
+	// file systems (e.g: incidents shared by multiple status pages), the incidents without
+	// systems are kept.
+	FilterIncidentsBySystem bool
+	Logger                  log.Logger
 }
 
 func (c *ReadRepositoryConfig) defaults() error {
@@ -57,11 +66,56 @@ func NewReadRepository(ctx context.Context, config ReadRepositoryConfig) (*ReadR
 		return nil, fmt.Errorf("could not load systems: %w", err)
 	}
 
-	r.Repository = memory.NewRepository(systems, *settings, incidents)
+	if config.FilterIncidentsBySystem {
+		incidents = filterIncidentsBySystem(incidents, systems)
+	}
+
+	pages := []model.Page{}
+	if config.PagesFS != nil {
+		pages, err = r.loadPages(config.PagesFS)
+		if err != nil {
+			return nil, fmt.Errorf("could not load pages: %w", err)
+		}
+	}
+
+	r.Repository = memory.NewRepository(systems, *settings, incidents, pages)
 
 	return r, nil
 }
 
+// filterIncidentsBySystem returns the incidents that affect the systems, removing the
+// systems that are not part of them.
+func filterIncidentsBySystem(incidents []model.IncidentReport, systems []model.System) []model.IncidentReport {
+	systemIDs := map[string]bool{}
+	for _, s := range systems {
+		systemIDs[s.ID] = true
+	}
+
+	filtered := []model.IncidentReport{}
+	for _, ir := range incidents {
+		// Incidents without systems affect everything.
+		if len(ir.SystemIDs) == 0 {
+			filtered = append(filtered, ir)
+			continue
+		}
+
+		irSystemIDs := []string{}
+		for _, id := range ir.SystemIDs {
+			if systemIDs[id] {
+				irSystemIDs = append(irSystemIDs, id)
+			}
+		}
+		if len(irSystemIDs) == 0 {
+			continue
+		}
+
+		ir.SystemIDs = irSystemIDs
+		filtered = append(filtered, ir)
+	}
+
+	return filtered
+}
+
 func (r ReadRepository) loadSystemsAndSettings(data string) ([]model.System, *model.StatusPageSettings, error) {
 	spec := apiv1.StactusV1{}
 	err := yaml.Unmarshal([]byte(data), &spec)
@@ -79,6 +133,7 @@ func (r ReadRepository) loadSystemsAndSettings(data string) ([]model.System, *mo
 			ID:          s.ID,
 			Name:        s.Name,
 			Description: s.Description,
+			Audience:    model.Audience(strings.TrimSpace(s.Audience)),
 		}
 
 		err := s.Validate()
@@ -97,16 +152,63 @@ func (r ReadRepository) loadSystemsAndSettings(data string) ([]model.System, *mo
 	if spec.Theme != nil {
 		switch {
 		case spec.Theme.Simple != nil:
-			theme.Simple = &model.ThemeSimple{}
+			theme.Simple = &model.ThemeSimple{
+				ColorScheme:  model.ColorScheme(spec.Theme.Simple.ColorScheme),
+				HighContrast: spec.Theme.Simple.HighContrast,
+			}
 			theme.OverrideTPLPath = spec.Theme.Simple.ThemePath
+			theme.TranslationsPath = spec.Theme.Simple.TranslationsPath
 
 		}
 	}
 
 	settings := &model.StatusPageSettings{
-		Name:  spec.Name,
-		URL:   spec.URL,
-		Theme: theme,
+		Name:      spec.Name,
+		URL:       spec.URL,
+		Theme:     theme,
+		Languages: spec.Languages,
+	}
+
+	for _, a := range spec.Audiences {
+		settings.Audiences = append(settings.Audiences, model.AudienceSettings{
+			Audience: model.Audience(strings.TrimSpace(a.Name)),
+			URL:      a.URL,
+		})
+	}
+
+	if spec.Feed != nil {
+		settings.Feed.HistoryItems = spec.Feed.HistoryItems
+		settings.Feed.Mode = model.FeedMode(spec.Feed.Mode)
+		settings.Feed.DisableAtom = spec.Feed.Atom != nil && !*spec.Feed.Atom
+		settings.Feed.DisableRSS = spec.Feed.RSS != nil && !*spec.Feed.RSS
+		settings.Feed.DisableJSON = spec.Feed.JSON != nil && !*spec.Feed.JSON
+	}
+
+	if spec.Metrics != nil {
+		settings.Metrics.Format = model.MetricsFormat(spec.Metrics.Format)
+		settings.Metrics.Namespace = spec.Metrics.Namespace
+		settings.Metrics.Labels = spec.Metrics.Labels
+	}
+
+	if spec.SEO != nil {
+		settings.SEO.NoIndex = spec.SEO.NoIndex
+		settings.SEO.Description = spec.SEO.Description
+		settings.SEO.Robots = spec.SEO.Robots
+	}
+
+	if spec.Markdown != nil {
+		settings.Markdown.DisableTables = spec.Markdown.Tables != nil && !*spec.Markdown.Tables
+		settings.Markdown.DisableStrikethrough = spec.Markdown.Strikethrough != nil && !*spec.Markdown.Strikethrough
+		settings.Markdown.DisableAutolinks = spec.Markdown.Autolinks != nil && !*spec.Markdown.Autolinks
+		settings.Markdown.DisableTaskLists = spec.Markdown.TaskLists != nil && !*spec.Markdown.TaskLists
+		settings.Markdown.DisableFootnotes = spec.Markdown.Footnotes != nil && !*spec.Markdown.Footnotes
+		settings.Markdown.RawHTML = spec.Markdown.RawHTML
+		settings.Markdown.HeadingAnchors = spec.Markdown.HeadingAnchors
+		settings.Markdown.CodeHighlightStyle = strings.TrimSpace(spec.Markdown.CodeHighlightStyle)
+		if spec.Markdown.ExternalLinks != nil {
+			settings.Markdown.ExternalLinkRel = strings.Join(strings.Fields(spec.Markdown.ExternalLinks.Rel), " ")
+			settings.Markdown.ExternalLinkTarget = strings.TrimSpace(spec.Markdown.ExternalLinks.Target)
+		}
 	}
 
 	err = settings.Validate()
@@ -136,7 +238,7 @@ func (r ReadRepository) loadIncidents(incidentFS fs.FS) ([]model.IncidentReport,
 			return fmt.Errorf("could not read manifest %s: %w", path, err)
 		}
 
-		is, err := r.loadIncident(rawData)
+		is, err := r.loadIncident(incidentFS, path, rawData)
 		if err != nil {
 			return fmt.Errorf("could not load incidents in %q: %w", path, err)
 		}
@@ -155,7 +257,7 @@ func (r ReadRepository) loadIncidents(incidentFS fs.FS) ([]model.IncidentReport,
 	return incidents, nil
 }
 
-func (r ReadRepository) loadIncident(data []byte) ([]model.IncidentReport, error) {
+func (r ReadRepository) loadIncident(incidentFS fs.FS, filePath string, data []byte) ([]model.IncidentReport, error) {
 	// In case we have multiple YAML in a single file.
 	models := []model.IncidentReport{}
 	for _, rawData := range splitYAML(data) {
@@ -169,7 +271,7 @@ func (r ReadRepository) loadIncident(data []byte) ([]model.IncidentReport, error
 			return nil, fmt.Errorf("could not unmarshall YAML incident file correctly: %w", err)
 		}
 
-		m, err := r.mapIncidentV1(spec)
+		m, err := r.mapIncidentV1(spec, incidentFS, path.Dir(filePath))
 		if err != nil {
 			return nil, fmt.Errorf("could not map spec to model: %w", err)
 		}
@@ -180,7 +282,9 @@ func (r ReadRepository) loadIncident(data []byte) ([]model.IncidentReport, error
 	return models, nil
 }
 
-func (r ReadRepository) mapIncidentV1(s apiv1.IncidentV1) (*model.IncidentReport, error) {
+// mapIncidentV1 maps the incident spec, dir is the incident file directory on the FS where the
+// files referenced relatively by the incident are.
+func (r ReadRepository) mapIncidentV1(s apiv1.IncidentV1, incidentFS fs.FS, dir string) (*model.IncidentReport, error) {
 	if s.Version != apiv1.IncidentVersionV1 {
 		return nil, fmt.Errorf("unsupported incident API version")
 	}
@@ -195,12 +299,47 @@ func (r ReadRepository) mapIncidentV1(s apiv1.IncidentV1) (*model.IncidentReport
 		return nil, err
 	}
 
+	// Load the files referenced by the timeline, these are relative to the incident file.
+	for i, ev := range tl {
+		descriptions := []string{ev.Description}
+		for _, d := range ev.LocalizedDescriptions {
+			descriptions = append(descriptions, d)
+		}
+		tl[i].Assets, err = loadMarkdownAssets(incidentFS, dir, descriptions...)
+		if err != nil {
+			return nil, fmt.Errorf("could not load timeline event assets: %w", err)
+		}
+
+		tl[i].PrivateNoteAssets, err = loadMarkdownAssets(incidentFS, dir, ev.PrivateNote)
+		if err != nil {
+			return nil, fmt.Errorf("could not load timeline event private note assets: %w", err)
+		}
+	}
+
 	m := &model.IncidentReport{
-		ID:        s.ID,
-		Name:      s.Name,
-		SystemIDs: s.Systems,
-		Impact:    impact,
-		Timeline:  tl,
+		ID:         s.ID,
+		Name:       s.Name,
+		SystemIDs:  s.Systems,
+		Impact:     impact,
+		Timeline:   tl,
+		Visibility: model.IncidentVisibility(strings.TrimSpace(strings.ToLower(s.Visibility))),
+	}
+
+	for lang, t := range s.I18n {
+		if t.Name == "" {
+			continue
+		}
+		if m.LocalizedNames == nil {
+			m.LocalizedNames = map[string]string{}
+		}
+		m.LocalizedNames[lang] = strings.TrimSpace(t.Name)
+	}
+
+	if s.Postmortem != nil {
+		m.Postmortem, err = mapPostmortem(*s.Postmortem, incidentFS, dir)
+		if err != nil {
+			return nil, fmt.Errorf("could not load postmortem: %w", err)
+		}
 	}
 
 	err = m.Validate()
@@ -211,6 +350,159 @@ func (r ReadRepository) mapIncidentV1(s apiv1.IncidentV1) (*model.IncidentReport
 	return m, nil
 }
 
+func (r ReadRepository) loadPages(pagesFS fs.FS) ([]model.Page, error) {
+	pages := []model.Page{}
+	slugs := map[string]string{}
+
+	err := fs.WalkDir(pagesFS, ".", func(path string, info fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Directories and non Markdown files don't need to be handled.
+		extension := strings.ToLower(filepath.Ext(path))
+		if info.IsDir() || (extension != ".md" && extension != ".markdown") {
+			return nil
+		}
+
+		rawData, err := fs.ReadFile(pagesFS, path)
+		if err != nil {
+			return fmt.Errorf("could not read page %s: %w", path, err)
+		}
+
+		p, err := r.loadPage(rawData)
+		if err != nil {
+			return fmt.Errorf("could not load page in %q: %w", path, err)
+		}
+
+		// By default use the file name as the slug.
+		if p.Slug == "" {
+			p.Slug = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		}
+
+		err = p.Validate()
+		if err != nil {
+			return fmt.Errorf("invalid page in %q: %w", path, err)
+		}
+
+		if other, ok := slugs[p.Slug]; ok {
+			return fmt.Errorf("page slug %q in %q is already used by %q", p.Slug, path, other)
+		}
+		slugs[p.Slug] = path
+
+		pages = append(pages, *p)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not walk directory: %w", err)
+	}
+
+	// Sort by navigation order, and title in case of same order.
+	sort.SliceStable(pages, func(i, j int) bool {
+		if pages[i].NavOrder != pages[j].NavOrder {
+			return pages[i].NavOrder < pages[j].NavOrder
+		}
+		return pages[i].Title < pages[j].Title
+	})
+
+	return pages, nil
+}
+
+func (r ReadRepository) loadPage(data []byte) (*model.Page, error) {
+	frontMatter, content, ok := splitFrontMatter(data)
+	if !ok {
+		return nil, fmt.Errorf("YAML front matter is required")
+	}
+
+	spec := apiv1.PageV1FrontMatter{}
+	err := yaml.Unmarshal([]byte(frontMatter), &spec)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshall YAML front matter correctly: %w", err)
+	}
+
+	return &model.Page{
+		Slug:     strings.TrimSpace(spec.Slug),
+		Title:    strings.TrimSpace(spec.Title),
+		NavOrder: spec.NavOrder,
+		Content:  content,
+	}, nil
+}
+
+func mapPostmortem(s apiv1.IncidentV1Postmortem, incidentFS fs.FS, dir string) (*model.Postmortem, error) {
+	content := s.Content
+	contentDir := dir
+	file := strings.TrimSpace(s.File)
+	if file != "" {
+		if strings.TrimSpace(content) != "" {
+			return nil, fmt.Errorf("postmortem content and file can't be used at the same time")
+		}
+
+		filePath := path.Join(dir, file)
+		data, err := fs.ReadFile(incidentFS, filePath)
+		if err != nil {
+			return nil, fmt.Errorf("could not read postmortem file %q: %w", file, err)
+		}
+		content = string(data)
+		contentDir = path.Dir(filePath)
+	}
+
+	pm := &model.Postmortem{Content: strings.TrimSpace(content)}
+
+	rawTS := strings.TrimSpace(s.TS)
+	if rawTS != "" {
+		ts, err := mapEventTS(time.Time{}, rawTS)
+		if err != nil {
+			return nil, fmt.Errorf("could not map postmortem timestamp: %q", rawTS)
+		}
+		pm.TS = ts.UTC()
+	}
+
+	assets, err := loadMarkdownAssets(incidentFS, contentDir, pm.Content)
+	if err != nil {
+		return nil, err
+	}
+	pm.Assets = assets
+
+	return pm, nil
+}
+
+// loadMarkdownAssets loads the files referenced relatively by the Markdown contents (e.g: images),
+// dir is the directory on the FS the references are relative to.
+func loadMarkdownAssets(fsys fs.FS, dir string, contents ...string) (map[string]model.Asset, error) {
+	refs := []string{}
+	for _, c := range contents {
+		refs = append(refs, utilhtml.MarkdownRelativeReferences(c)...)
+	}
+
+	var assets map[string]model.Asset
+	for _, ref := range refs {
+		if _, ok := assets[ref]; ok {
+			continue
+		}
+
+		// Ignore the query and fragment of the reference.
+		refPath, _, _ := strings.Cut(ref, "#")
+		refPath, _, _ = strings.Cut(refPath, "?")
+		if p, err := url.PathUnescape(refPath); err == nil {
+			refPath = p
+		}
+
+		assetPath := path.Join(dir, refPath)
+		data, err := fs.ReadFile(fsys, assetPath)
+		if err != nil {
+			return nil, fmt.Errorf("could not read %q asset: %w", ref, err)
+		}
+
+		if assets == nil {
+			assets = map[string]model.Asset{}
+		}
+		assets[ref] = model.Asset{Path: assetPath, Data: data}
+	}
+
+	return assets, nil
+}
+
 func mapImpact(s string) (model.IncidentImpact, error) {
 	switch strings.TrimSpace(strings.ToLower(s)) {
 	case "", "none":
@@ -240,11 +532,24 @@ func mapTimeline(tl []apiv1.IncidentV1TimelineEvent) ([]model.IncidentReportEven
 			return nil, fmt.Errorf("could not map event timestamp: %q", rawTS)
 		}
 
-		mtl = append(mtl, model.IncidentReportEvent{
+		ev := model.IncidentReportEvent{
 			TS:          ts.UTC(),
 			Kind:        mapEventKind(e),
 			Description: strings.TrimSpace(e.Description),
-		})
+			Audience:    model.Audience(strings.TrimSpace(e.Audience)),
+			PrivateNote: strings.TrimSpace(e.PrivateNote),
+		}
+		for lang, t := range e.I18n {
+			if t.Description == "" {
+				continue
+			}
+			if ev.LocalizedDescriptions == nil {
+				ev.LocalizedDescriptions = map[string]string{}
+			}
+			ev.LocalizedDescriptions[lang] = strings.TrimSpace(t.Description)
+		}
+
+		mtl = append(mtl, ev)
 	}
 
 	return mtl, nil
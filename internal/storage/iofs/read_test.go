@@ -58,7 +58,7 @@ timeline:
 }
 
 func getIRForTSFormatsResult(ts1, ts2 time.Time) model.IncidentReport {
-	return model.IncidentReport{ID: "test-0001", Name: "incident 1", SystemIDs: []string{"system1"}, Impact: "minor",
+	return model.IncidentReport{ID: "test-0001", Name: "incident 1", SystemIDs: []string{"system1"}, Impact: "minor", Visibility: model.IncidentVisibilityPublic,
 		Start:    time.Date(2024, 9, 13, 5, 42, 0, 0, time.UTC),
 		End:      time.Date(2024, 9, 13, 5, 59, 0, 0, time.UTC),
 		Duration: ts2.Sub(ts1),
@@ -106,7 +106,7 @@ func TestReadRepository(t *testing.T) {
 			expIRs:      []model.IncidentReport{},
 		},
 
-		"Customizing the simple theme should allow settings a custom template directory.": {
+		"Customizing the simple theme should allow settings a custom template directory and color scheme.": {
 			fs: func() fs.FS { return fstest.MapFS{} },
 			stactusFile: `
 version: stactus/v1
@@ -115,6 +115,8 @@ url: https://something.test.test.somethingdsadsadsad.com
 theme:
   simple:
     themePath: /tmp/custom-templates
+    colorScheme: dark
+    highContrast: true
 systems:
   - id: system1
     name: System 1
@@ -128,13 +130,244 @@ systems:
 				URL:  "https://something.test.test.somethingdsadsadsad.com",
 				Theme: model.Theme{
 					OverrideTPLPath: "/tmp/custom-templates",
-					Simple:          &model.ThemeSimple{},
+					Simple: &model.ThemeSimple{
+						ColorScheme:  model.ColorSchemeDark,
+						HighContrast: true,
+					},
+				},
+			},
+			expSystems: testSystems,
+			expIRs:     []model.IncidentReport{},
+		},
+
+		"Customizing the feed should allow setting the history items, mode and formats.": {
+			fs: func() fs.FS { return fstest.MapFS{} },
+			stactusFile: `
+version: stactus/v1
+name: SomethingIO
+url: https://something.test.test.somethingdsadsadsad.com
+feed:
+  historyItems: 50
+  mode: update
+  rss: false
+  json: true
+systems:
+  - id: system1
+    name: System 1
+    description: This is a description of system1
+  - id: system2
+    name: System 2
+    description: This is a description of system2
+`,
+			expSettings: model.StatusPageSettings{
+				Name:  "SomethingIO",
+				URL:   "https://something.test.test.somethingdsadsadsad.com",
+				Theme: model.Theme{Simple: &model.ThemeSimple{}},
+				Feed:  model.FeedSettings{HistoryItems: 50, Mode: model.FeedModeUpdate, DisableRSS: true},
+			},
+			expSystems: testSystems,
+			expIRs:     []model.IncidentReport{},
+		},
+
+		"Customizing the metrics should allow setting the format, namespace and labels.": {
+			fs: func() fs.FS { return fstest.MapFS{} },
+			stactusFile: `
+version: stactus/v1
+name: SomethingIO
+url: https://something.test.test.somethingdsadsadsad.com
+metrics:
+  format: openmetrics
+  namespace: something
+  labels:
+    environment: prod
+    region: eu-west-1
+systems:
+  - id: system1
+    name: System 1
+    description: This is a description of system1
+  - id: system2
+    name: System 2
+    description: This is a description of system2
+`,
+			expSettings: model.StatusPageSettings{
+				Name:  "SomethingIO",
+				URL:   "https://something.test.test.somethingdsadsadsad.com",
+				Theme: model.Theme{Simple: &model.ThemeSimple{}},
+				Metrics: model.MetricsSettings{
+					Format:    model.MetricsFormatOpenMetrics,
+					Namespace: "something",
+					Labels:    map[string]string{"environment": "prod", "region": "eu-west-1"},
+				},
+			},
+			expSystems: testSystems,
+			expIRs:     []model.IncidentReport{},
+		},
+
+		"Customizing the SEO should allow setting no index, description and robots.": {
+			fs: func() fs.FS { return fstest.MapFS{} },
+			stactusFile: `
+version: stactus/v1
+name: SomethingIO
+url: https://something.test.test.somethingdsadsadsad.com
+seo:
+  noIndex: true
+  description: Internal status page
+  robots: |
+    User-agent: *
+    Disallow: /ir/
+systems:
+  - id: system1
+    name: System 1
+    description: This is a description of system1
+  - id: system2
+    name: System 2
+    description: This is a description of system2
+`,
+			expSettings: model.StatusPageSettings{
+				Name:  "SomethingIO",
+				URL:   "https://something.test.test.somethingdsadsadsad.com",
+				Theme: model.Theme{Simple: &model.ThemeSimple{}},
+				SEO: model.SEOSettings{
+					NoIndex:     true,
+					Description: "Internal status page",
+					Robots:      "User-agent: *\nDisallow: /ir/\n",
+				},
+			},
+			expSystems: testSystems,
+			expIRs:     []model.IncidentReport{},
+		},
+
+		"Customizing the Markdown should allow setting the extensions, highlighting, anchors and external links.": {
+			fs: func() fs.FS { return fstest.MapFS{} },
+			stactusFile: `
+version: stactus/v1
+name: SomethingIO
+url: https://something.test.test.somethingdsadsadsad.com
+markdown:
+  tables: true
+  strikethrough: false
+  footnotes: false
+  rawHTML: true
+  headingAnchors: true
+  codeHighlightStyle: github
+  externalLinks:
+    rel: " noopener   nofollow "
+    target: _blank
+systems:
+  - id: system1
+    name: System 1
+    description: This is a description of system1
+  - id: system2
+    name: System 2
+    description: This is a description of system2
+`,
+			expSettings: model.StatusPageSettings{
+				Name:  "SomethingIO",
+				URL:   "https://something.test.test.somethingdsadsadsad.com",
+				Theme: model.Theme{Simple: &model.ThemeSimple{}},
+				Markdown: model.MarkdownSettings{
+					DisableStrikethrough: true,
+					DisableFootnotes:     true,
+					RawHTML:              true,
+					HeadingAnchors:       true,
+					CodeHighlightStyle:   "github",
+					ExternalLinkRel:      "noopener nofollow",
+					ExternalLinkTarget:   "_blank",
+				},
+			},
+			expSystems: testSystems,
+			expIRs:     []model.IncidentReport{},
+		},
+
+		"Customizing the languages and translations should allow multiple languages.": {
+			fs: func() fs.FS { return fstest.MapFS{} },
+			stactusFile: `
+version: stactus/v1
+name: SomethingIO
+url: https://something.test.test.somethingdsadsadsad.com
+languages: [en, es]
+theme:
+  simple:
+    translationsPath: /tmp/translations
+systems:
+  - id: system1
+    name: System 1
+    description: This is a description of system1
+  - id: system2
+    name: System 2
+    description: This is a description of system2
+`,
+			expSettings: model.StatusPageSettings{
+				Name: "SomethingIO",
+				URL:  "https://something.test.test.somethingdsadsadsad.com",
+				Theme: model.Theme{
+					TranslationsPath: "/tmp/translations",
+					Simple:           &model.ThemeSimple{},
 				},
+				Languages: []string{"en", "es"},
 			},
 			expSystems: testSystems,
 			expIRs:     []model.IncidentReport{},
 		},
 
+		"Invalid languages should fail.": {
+			fs: func() fs.FS { return fstest.MapFS{} },
+			stactusFile: `
+version: stactus/v1
+name: SomethingIO
+url: https://something.test.test.somethingdsadsadsad.com
+languages: [en, Spanish]
+systems:
+  - id: system1
+    name: System 1
+`,
+			expErr: true,
+		},
+
+		"Incident reports with translations should be loaded correctly.": {
+			fs: func() fs.FS {
+				fs := fstest.MapFS{}
+				fs["ir.yaml"] = &fstest.MapFile{Data: []byte(`
+version: incident/v1
+id: test-0001
+name: incident 1
+i18n:
+  es:
+    name: incidente 1
+impact: minor
+systems: ["system1"]
+timeline:
+  - ts: 2024/09/13 05:42
+    investigating: true
+    description: desc 1
+    i18n:
+      es:
+        description: desc 1 es
+
+  - ts: 2024/09/13 05:59
+    resolved: true
+    description: desc 2
+`)}
+				return fs
+			},
+			stactusFile: testStatusFile,
+			expSettings: testSettings,
+			expSystems:  testSystems,
+			expIRs: []model.IncidentReport{
+				{ID: "test-0001", Name: "incident 1", SystemIDs: []string{"system1"}, Impact: "minor", Visibility: model.IncidentVisibilityPublic,
+					Start:          time.Date(2024, 9, 13, 5, 42, 0, 0, time.UTC),
+					End:            time.Date(2024, 9, 13, 5, 59, 0, 0, time.UTC),
+					Duration:       17 * time.Minute,
+					LocalizedNames: map[string]string{"es": "incidente 1"},
+					Timeline: []model.IncidentReportEvent{
+						{Description: "desc 2", Kind: model.IncidentUpdateKindResolved, TS: time.Date(2024, 9, 13, 5, 59, 0, 0, time.UTC)},
+						{Description: "desc 1", Kind: model.IncidentUpdateKindInvestigating, TS: time.Date(2024, 9, 13, 5, 42, 0, 0, time.UTC),
+							LocalizedDescriptions: map[string]string{"es": "desc 1 es"}},
+					},
+				},
+			},
+		},
+
 		"Incident reports should be loaded correctly.": {
 			fs: func() fs.FS {
 				fs := fstest.MapFS{}
@@ -162,7 +395,7 @@ timeline:
 			expSettings: testSettings,
 			expSystems:  testSystems,
 			expIRs: []model.IncidentReport{
-				{ID: "test-0001", Name: "incident 1", SystemIDs: []string{"system1"}, Impact: "minor",
+				{ID: "test-0001", Name: "incident 1", SystemIDs: []string{"system1"}, Impact: "minor", Visibility: model.IncidentVisibilityPublic,
 					Start:    time.Date(2024, 9, 13, 5, 42, 0, 0, time.UTC),
 					End:      time.Date(2024, 9, 13, 5, 59, 0, 0, time.UTC),
 					Duration: 17 * time.Minute,
@@ -175,6 +408,343 @@ timeline:
 			},
 		},
 
+		"Incident reports visibility should be loaded correctly.": {
+			fs: func() fs.FS {
+				fs := fstest.MapFS{}
+				fs["ir.yaml"] = &fstest.MapFile{Data: []byte(`
+version: incident/v1
+id: test-0001
+name: incident 1
+impact: minor
+systems: ["system1"]
+visibility: draft
+timeline:
+  - ts: 2024/09/13 05:42
+    investigating: true
+    description: desc 1
+`)}
+				return fs
+			},
+			stactusFile: testStatusFile,
+			expSettings: testSettings,
+			expSystems:  testSystems,
+			expIRs: []model.IncidentReport{
+				{ID: "test-0001", Name: "incident 1", SystemIDs: []string{"system1"}, Impact: "minor", Visibility: model.IncidentVisibilityDraft,
+					Start: time.Date(2024, 9, 13, 5, 42, 0, 0, time.UTC),
+					Timeline: []model.IncidentReportEvent{
+						{Description: "desc 1", Kind: model.IncidentUpdateKindInvestigating, TS: time.Date(2024, 9, 13, 5, 42, 0, 0, time.UTC)},
+					},
+				},
+			},
+		},
+
+		"Audiences should be loaded correctly.": {
+			fs: func() fs.FS {
+				fs := fstest.MapFS{}
+				fs["ir.yaml"] = &fstest.MapFile{Data: []byte(`
+version: incident/v1
+id: test-0001
+name: incident 1
+impact: minor
+systems: ["system1"]
+timeline:
+  - ts: 2024/09/13 05:42
+    description: desc 1
+    privateNote: note 1
+  - ts: 2024/09/13 05:50
+    description: desc 2
+    audience: internal
+`)}
+				return fs
+			},
+			stactusFile: `
+version: stactus/v1
+name: SomethingIO
+url: https://something.test.test.somethingdsadsadsad.com
+audiences:
+  - name: public
+  - name: internal
+    url: https://internal.something.test/
+systems:
+  - id: system1
+    name: System 1
+    audience: internal
+`,
+			expSettings: model.StatusPageSettings{
+				Name:  "SomethingIO",
+				URL:   "https://something.test.test.somethingdsadsadsad.com",
+				Theme: model.Theme{Simple: &model.ThemeSimple{}},
+				Audiences: []model.AudienceSettings{
+					{Audience: model.AudiencePublic},
+					{Audience: model.AudienceInternal, URL: "https://internal.something.test"},
+				},
+			},
+			expSystems: []model.System{
+				{ID: "system1", Name: "System 1", Audience: model.AudienceInternal},
+			},
+			expIRs: []model.IncidentReport{
+				{ID: "test-0001", Name: "incident 1", SystemIDs: []string{"system1"}, Impact: "minor", Visibility: model.IncidentVisibilityPublic,
+					Start: time.Date(2024, 9, 13, 5, 42, 0, 0, time.UTC),
+					Timeline: []model.IncidentReportEvent{
+						{Description: "desc 2", Kind: model.IncidentUpdateKindUpdate, TS: time.Date(2024, 9, 13, 5, 50, 0, 0, time.UTC), Audience: model.AudienceInternal},
+						{Description: "desc 1", Kind: model.IncidentUpdateKindUpdate, TS: time.Date(2024, 9, 13, 5, 42, 0, 0, time.UTC), PrivateNote: "note 1"},
+					},
+				},
+			},
+		},
+
+		"Incident report inline postmortems should be loaded correctly.": {
+			fs: func() fs.FS {
+				fs := fstest.MapFS{}
+				fs["ir.yaml"] = &fstest.MapFile{Data: []byte(`
+version: incident/v1
+id: test-0001
+name: incident 1
+timeline:
+  - ts: 2024/09/13 05:42
+    resolved: true
+    description: desc 1
+postmortem:
+  ts: 2024/09/14 10:00
+  content: |
+    ## Root cause
+`)}
+				return fs
+			},
+			stactusFile: testStatusFile,
+			expSettings: testSettings,
+			expSystems:  testSystems,
+			expIRs: []model.IncidentReport{
+				{ID: "test-0001", Name: "incident 1", Impact: "none", Visibility: model.IncidentVisibilityPublic,
+					Start: time.Date(2024, 9, 13, 5, 42, 0, 0, time.UTC),
+					End:   time.Date(2024, 9, 13, 5, 42, 0, 0, time.UTC),
+					Timeline: []model.IncidentReportEvent{
+						{Description: "desc 1", Kind: model.IncidentUpdateKindResolved, TS: time.Date(2024, 9, 13, 5, 42, 0, 0, time.UTC)},
+					},
+					Postmortem: &model.Postmortem{
+						Content: "## Root cause",
+						TS:      time.Date(2024, 9, 14, 10, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+
+		"Incident report postmortem files should be loaded correctly with their relative images.": {
+			fs: func() fs.FS {
+				fs := fstest.MapFS{}
+				fs["2024/ir.yaml"] = &fstest.MapFile{Data: []byte(`
+version: incident/v1
+id: test-0001
+name: incident 1
+timeline:
+  - ts: 2024/09/13 05:42
+    resolved: true
+    description: desc 1
+postmortem:
+  file: ./ir-postmortem.md
+`)}
+				fs["2024/ir-postmortem.md"] = &fstest.MapFile{Data: []byte("## Root cause\n\n![latency](./img/latency.png) ![logo](https://example.com/logo.png)\n")}
+				fs["2024/img/latency.png"] = &fstest.MapFile{Data: []byte("png")}
+				return fs
+			},
+			stactusFile: testStatusFile,
+			expSettings: testSettings,
+			expSystems:  testSystems,
+			expIRs: []model.IncidentReport{
+				{ID: "test-0001", Name: "incident 1", Impact: "none", Visibility: model.IncidentVisibilityPublic,
+					Start: time.Date(2024, 9, 13, 5, 42, 0, 0, time.UTC),
+					End:   time.Date(2024, 9, 13, 5, 42, 0, 0, time.UTC),
+					Timeline: []model.IncidentReportEvent{
+						{Description: "desc 1", Kind: model.IncidentUpdateKindResolved, TS: time.Date(2024, 9, 13, 5, 42, 0, 0, time.UTC)},
+					},
+					Postmortem: &model.Postmortem{
+						Content: "## Root cause\n\n![latency](./img/latency.png) ![logo](https://example.com/logo.png)",
+						TS:      time.Date(2024, 9, 13, 5, 42, 0, 0, time.UTC),
+						Assets: map[string]model.Asset{
+							"./img/latency.png": {Path: "2024/img/latency.png", Data: []byte("png")},
+						},
+					},
+				},
+			},
+		},
+
+		"Incident report postmortems with missing images should fail.": {
+			fs: func() fs.FS {
+				fs := fstest.MapFS{}
+				fs["ir.yaml"] = &fstest.MapFile{Data: []byte(`
+version: incident/v1
+id: test-0001
+name: incident 1
+timeline:
+  - ts: 2024/09/13 05:42
+    description: desc 1
+postmortem:
+  content: "![latency](./img/latency.png)"
+`)}
+				return fs
+			},
+			stactusFile: testStatusFile,
+			expErr:      true,
+		},
+
+		"Incident report postmortems with content and file should fail.": {
+			fs: func() fs.FS {
+				fs := fstest.MapFS{}
+				fs["ir.yaml"] = &fstest.MapFile{Data: []byte(`
+version: incident/v1
+id: test-0001
+name: incident 1
+timeline:
+  - ts: 2024/09/13 05:42
+    description: desc 1
+postmortem:
+  content: "## Root cause"
+  file: ./ir-postmortem.md
+`)}
+				fs["ir-postmortem.md"] = &fstest.MapFile{Data: []byte("## Root cause")}
+				return fs
+			},
+			stactusFile: testStatusFile,
+			expErr:      true,
+		},
+
+		"Incident report relative references on the timeline should be loaded as assets.": {
+			fs: func() fs.FS {
+				fs := fstest.MapFS{}
+				fs["2024/ir.yaml"] = &fstest.MapFile{Data: []byte(`
+version: incident/v1
+id: test-0001
+name: incident 1
+timeline:
+  - ts: 2024/09/13 05:42
+    resolved: true
+    description: "![graph](./img/graph.png) [logs](../logs.txt#L2) [docs](https://example.com)"
+    privateNote: "![db](./img/db.png)"
+`)}
+				fs["2024/img/graph.png"] = &fstest.MapFile{Data: []byte("png")}
+				fs["2024/img/db.png"] = &fstest.MapFile{Data: []byte("db png")}
+				fs["logs.txt"] = &fstest.MapFile{Data: []byte("logs")}
+				return fs
+			},
+			stactusFile: testStatusFile,
+			expSettings: testSettings,
+			expSystems:  testSystems,
+			expIRs: []model.IncidentReport{
+				{ID: "test-0001", Name: "incident 1", Impact: "none", Visibility: model.IncidentVisibilityPublic,
+					Start: time.Date(2024, 9, 13, 5, 42, 0, 0, time.UTC),
+					End:   time.Date(2024, 9, 13, 5, 42, 0, 0, time.UTC),
+					Timeline: []model.IncidentReportEvent{
+						{
+							Description: "![graph](./img/graph.png) [logs](../logs.txt#L2) [docs](https://example.com)",
+							Kind:        model.IncidentUpdateKindResolved,
+							TS:          time.Date(2024, 9, 13, 5, 42, 0, 0, time.UTC),
+							PrivateNote: "![db](./img/db.png)",
+							Assets: map[string]model.Asset{
+								"./img/graph.png": {Path: "2024/img/graph.png", Data: []byte("png")},
+								"../logs.txt#L2":  {Path: "logs.txt", Data: []byte("logs")},
+							},
+							PrivateNoteAssets: map[string]model.Asset{
+								"./img/db.png": {Path: "2024/img/db.png", Data: []byte("db png")},
+							},
+						},
+					},
+				},
+			},
+		},
+
+		"Incident report relative references on the timeline raw HTML should be loaded as assets.": {
+			fs: func() fs.FS {
+				fs := fstest.MapFS{}
+				fs["2024/ir.yaml"] = &fstest.MapFile{Data: []byte(`
+version: incident/v1
+id: test-0001
+name: incident 1
+timeline:
+  - ts: 2024/09/13 05:42
+    resolved: true
+    description: |
+      Latency <img src="./img/graph.png" width="100"> graph.
+
+      <p><a href="../logs.txt">logs</a> <a href="https://example.com">docs</a></p>
+`)}
+				fs["2024/img/graph.png"] = &fstest.MapFile{Data: []byte("png")}
+				fs["logs.txt"] = &fstest.MapFile{Data: []byte("logs")}
+				return fs
+			},
+			stactusFile: testStatusFile,
+			expSettings: testSettings,
+			expSystems:  testSystems,
+			expIRs: []model.IncidentReport{
+				{ID: "test-0001", Name: "incident 1", Impact: "none", Visibility: model.IncidentVisibilityPublic,
+					Start: time.Date(2024, 9, 13, 5, 42, 0, 0, time.UTC),
+					End:   time.Date(2024, 9, 13, 5, 42, 0, 0, time.UTC),
+					Timeline: []model.IncidentReportEvent{
+						{
+							Description: "Latency <img src=\"./img/graph.png\" width=\"100\"> graph.\n\n<p><a href=\"../logs.txt\">logs</a> <a href=\"https://example.com\">docs</a></p>",
+							Kind:        model.IncidentUpdateKindResolved,
+							TS:          time.Date(2024, 9, 13, 5, 42, 0, 0, time.UTC),
+							Assets: map[string]model.Asset{
+								"./img/graph.png": {Path: "2024/img/graph.png", Data: []byte("png")},
+								"../logs.txt":     {Path: "logs.txt", Data: []byte("logs")},
+							},
+						},
+					},
+				},
+			},
+		},
+
+		"Incident report relative references on the timeline that can't be resolved should fail.": {
+			fs: func() fs.FS {
+				fs := fstest.MapFS{}
+				fs["2024/ir.yaml"] = &fstest.MapFile{Data: []byte(`
+version: incident/v1
+id: test-0001
+name: incident 1
+timeline:
+  - ts: 2024/09/13 05:42
+    description: "[logs](./logs.txt)"
+`)}
+				return fs
+			},
+			stactusFile: testStatusFile,
+			expErr:      true,
+		},
+
+		"Incident report relative references outside the incidents directory should fail.": {
+			fs: func() fs.FS {
+				fs := fstest.MapFS{}
+				fs["ir.yaml"] = &fstest.MapFile{Data: []byte(`
+version: incident/v1
+id: test-0001
+name: incident 1
+timeline:
+  - ts: 2024/09/13 05:42
+    description: "![secret](../secret.png)"
+`)}
+				return fs
+			},
+			stactusFile: testStatusFile,
+			expErr:      true,
+		},
+
+		"Incident reports with an unknown visibility should fail.": {
+			fs: func() fs.FS {
+				fs := fstest.MapFS{}
+				fs["ir.yaml"] = &fstest.MapFile{Data: []byte(`
+version: incident/v1
+id: test-0001
+name: incident 1
+visibility: secret
+timeline:
+  - ts: 2024/09/13 05:42
+    description: desc 1
+`)}
+				return fs
+			},
+			stactusFile: testStatusFile,
+			expErr:      true,
+		},
+
 		"Different TS formats should be loaded correctly (pretty format).": {
 			fs: func() fs.FS {
 				fs := fstest.MapFS{}
@@ -293,3 +863,161 @@ timeline:
 		})
 	}
 }
+
+func TestReadRepositoryPages(t *testing.T) {
+	tests := map[string]struct {
+		pagesFS  func() fs.FS
+		expPages []model.Page
+		expErr   bool
+	}{
+		"Not having pages should return empty pages.": {
+			pagesFS:  func() fs.FS { return fstest.MapFS{} },
+			expPages: []model.Page{},
+		},
+
+		"Pages should be loaded correctly and sorted by navigation order.": {
+			pagesFS: func() fs.FS {
+				return fstest.MapFS{
+					"about.md": &fstest.MapFile{Data: []byte(`---
+title: About this status page
+navOrder: 2
+---
+
+This is **about** us.
+`)},
+					"support/hours.md": &fstest.MapFile{Data: []byte(`---
+title: Support hours
+slug: support-hours
+navOrder: 1
+---
+From 9 to 5.
+`)},
+					"ignored.txt": &fstest.MapFile{Data: []byte(`something`)},
+				}
+			},
+			expPages: []model.Page{
+				{Slug: "support-hours", Title: "Support hours", NavOrder: 1, Content: "From 9 to 5."},
+				{Slug: "about", Title: "About this status page", NavOrder: 2, Content: "This is **about** us."},
+			},
+		},
+
+		"Pages without front matter should fail.": {
+			pagesFS: func() fs.FS {
+				return fstest.MapFS{
+					"about.md": &fstest.MapFile{Data: []byte(`This is **about** us.`)},
+				}
+			},
+			expErr: true,
+		},
+
+		"Pages without title should fail.": {
+			pagesFS: func() fs.FS {
+				return fstest.MapFS{
+					"about.md": &fstest.MapFile{Data: []byte("---\nnavOrder: 1\n---\nThis is **about** us.")},
+				}
+			},
+			expErr: true,
+		},
+
+		"Pages with duplicated slugs should fail.": {
+			pagesFS: func() fs.FS {
+				return fstest.MapFS{
+					"about.md":  &fstest.MapFile{Data: []byte("---\ntitle: About 1\n---\nAbout 1")},
+					"about2.md": &fstest.MapFile{Data: []byte("---\ntitle: About 2\nslug: about\n---\nAbout 2")},
+				}
+			},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			repo, err := iofs.NewReadRepository(context.TODO(), iofs.ReadRepositoryConfig{
+				IncidentsFS:     fstest.MapFS{},
+				PagesFS:         test.pagesFS(),
+				StactusFileData: testStatusFile,
+			})
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				gotPages, _ := repo.ListAllPages(context.TODO())
+				assert.Equal(test.expPages, gotPages)
+			}
+		})
+	}
+}
+
+func TestReadRepositoryFilterIncidentsBySystem(t *testing.T) {
+	irFile := func(id string, systems string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte(fmt.Sprintf(`
+version: incident/v1
+id: %s
+name: incident %s
+systems: %s
+timeline:
+  - ts: 2024-09-13T05:42:00Z
+    description: ts1
+`, id, id, systems))}
+	}
+
+	tests := map[string]struct {
+		filter    bool
+		expIRs    []string
+		expSystem map[string][]string
+	}{
+		"Without filtering, all the incidents should be loaded.": {
+			filter: false,
+			expIRs: []string{"ir1", "ir2", "ir3", "ir4"},
+			expSystem: map[string][]string{
+				"ir1": {"system1"},
+				"ir2": {"system1", "other"},
+				"ir3": {"other"},
+				"ir4": nil,
+			},
+		},
+
+		"Filtering should ignore the incidents of other systems and remove the other systems.": {
+			filter: true,
+			expIRs: []string{"ir1", "ir2", "ir4"},
+			expSystem: map[string][]string{
+				"ir1": {"system1"},
+				"ir2": {"system1"},
+				"ir4": nil,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			repo, err := iofs.NewReadRepository(context.TODO(), iofs.ReadRepositoryConfig{
+				IncidentsFS: fstest.MapFS{
+					"ir1.yaml": irFile("ir1", `["system1"]`),
+					"ir2.yaml": irFile("ir2", `["system1", "other"]`),
+					"ir3.yaml": irFile("ir3", `["other"]`),
+					"ir4.yaml": irFile("ir4", `[]`),
+				},
+				StactusFileData:         testStatusFile,
+				FilterIncidentsBySystem: test.filter,
+			})
+			if assert.NoError(err) {
+				gotIRs, _ := repo.ListAllIncidentReports(context.TODO())
+				gotIDs := []string{}
+				gotSystems := map[string][]string{}
+				for _, ir := range gotIRs {
+					gotIDs = append(gotIDs, ir.ID)
+					if len(ir.SystemIDs) > 0 {
+						gotSystems[ir.ID] = ir.SystemIDs
+					} else {
+						gotSystems[ir.ID] = nil
+					}
+				}
+				assert.ElementsMatch(test.expIRs, gotIDs)
+				assert.Equal(test.expSystem, gotSystems)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package iofs
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/slok/stactus/internal/model"
+	apiv1 "github.com/slok/stactus/pkg/api/v1"
+)
+
+// LoadWorkspace loads a workspace from its YAML file data.
+func LoadWorkspace(data string) (*model.Workspace, error) {
+	spec := apiv1.WorkspaceV1{}
+	err := yaml.Unmarshal([]byte(data), &spec)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshall YAML workspace file correctly: %w", err)
+	}
+
+	if spec.Version != apiv1.WorkspaceVersionV1 {
+		return nil, fmt.Errorf("unsupported workspace API version")
+	}
+
+	w := &model.Workspace{
+		IncidentsPath: spec.IncidentsPath,
+	}
+	for _, p := range spec.Pages {
+		w.Pages = append(w.Pages, model.WorkspacePage{
+			ID:              strings.TrimSpace(p.ID),
+			StactusFilePath: p.StactusFile,
+			OutPath:         p.OutPath,
+		})
+	}
+
+	err = w.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid workspace: %w", err)
+	}
+
+	return w, nil
+}
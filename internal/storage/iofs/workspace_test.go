@@ -0,0 +1,86 @@
+package iofs_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/slok/stactus/internal/model"
+	"github.com/slok/stactus/internal/storage/iofs"
+)
+
+func TestLoadWorkspace(t *testing.T) {
+	tests := map[string]struct {
+		data         string
+		expWorkspace *model.Workspace
+		expErr       bool
+	}{
+		"An empty workspace file should fail.": {
+			data:   "",
+			expErr: true,
+		},
+
+		"An unknown version should fail.": {
+			data: `
+version: workspace/v2
+pages:
+  - id: product-a
+    stactusFile: a/stactus.yaml
+`,
+			expErr: true,
+		},
+
+		"A workspace without pages should fail.": {
+			data:   "version: workspace/v1\npages: []",
+			expErr: true,
+		},
+
+		"A correct workspace should load the pages with the defaults.": {
+			data: `
+version: workspace/v1
+pages:
+  - id: product-a
+    stactusFile: a/stactus.yaml
+  - id: product-b
+    stactusFile: b/stactus.yaml
+    outPath: b
+`,
+			expWorkspace: &model.Workspace{
+				IncidentsPath: "incidents",
+				Pages: []model.WorkspacePage{
+					{ID: "product-a", StactusFilePath: "a/stactus.yaml", OutPath: "product-a"},
+					{ID: "product-b", StactusFilePath: "b/stactus.yaml", OutPath: "b"},
+				},
+			},
+		},
+
+		"A workspace should allow setting the shared incidents path.": {
+			data: `
+version: workspace/v1
+incidentsPath: shared/incidents
+pages:
+  - id: product-a
+    stactusFile: a/stactus.yaml
+`,
+			expWorkspace: &model.Workspace{
+				IncidentsPath: "shared/incidents",
+				Pages: []model.WorkspacePage{
+					{ID: "product-a", StactusFilePath: "a/stactus.yaml", OutPath: "product-a"},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			gotWorkspace, err := iofs.LoadWorkspace(test.data)
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expWorkspace, gotWorkspace)
+			}
+		})
+	}
+}
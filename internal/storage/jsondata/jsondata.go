@@ -0,0 +1,285 @@
+package jsondata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/invopop/jsonschema"
+
+	"github.com/slok/stactus/internal/conventions"
+	"github.com/slok/stactus/internal/model"
+	utilfs "github.com/slok/stactus/internal/util/fs"
+	apiv1 "github.com/slok/stactus/pkg/api/v1"
+)
+
+type RepositoryConfig struct {
+	FileManager         utilfs.FileManager
+	OutPath             string
+	HistoryItemsPerPage int
+	TimeNow             func() time.Time
+}
+
+func (c *RepositoryConfig) defaults() error {
+	if c.FileManager == nil {
+		c.FileManager = utilfs.StdFileManager
+	}
+
+	if c.OutPath == "" {
+		return fmt.Errorf("out path is required")
+	}
+	c.OutPath = filepath.Clean(c.OutPath)
+
+	if c.HistoryItemsPerPage < 0 {
+		return fmt.Errorf("history items per page can't be negative")
+	}
+
+	if c.HistoryItemsPerPage == 0 {
+		c.HistoryItemsPerPage = 50
+	}
+
+	if c.TimeNow == nil {
+		c.TimeNow = func() time.Time { return time.Now().UTC() }
+	}
+
+	return nil
+}
+
+// Repository knows how to export the status page data as versioned JSON documents
+// (with their JSON schemas), this is the public data contract of the status page,
+// independent of the theme.
+type Repository struct {
+	fileManager         utilfs.FileManager
+	outPath             string
+	historyItemsPerPage int
+	timeNow             func() time.Time
+}
+
+func NewFSRepository(config RepositoryConfig) (*Repository, error) {
+	err := config.defaults()
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return &Repository{
+		fileManager:         config.FileManager,
+		outPath:             config.OutPath,
+		historyItemsPerPage: config.HistoryItemsPerPage,
+		timeNow:             config.TimeNow,
+	}, nil
+}
+
+// Schema names.
+const (
+	schemaIndex    = "index"
+	schemaHistory  = "history"
+	schemaIncident = "incident"
+)
+
+func (r Repository) CreateData(ctx context.Context, ui model.UI) error {
+	err := r.createSchemas(ctx, ui)
+	if err != nil {
+		return fmt.Errorf("could not create JSON schemas: %w", err)
+	}
+
+	err = r.createIndex(ctx, ui)
+	if err != nil {
+		return fmt.Errorf("could not create index: %w", err)
+	}
+
+	err = r.createHistory(ctx, ui)
+	if err != nil {
+		return fmt.Errorf("could not create history: %w", err)
+	}
+
+	err = r.createIncidents(ctx, ui)
+	if err != nil {
+		return fmt.Errorf("could not create incidents: %w", err)
+	}
+
+	return nil
+}
+
+func (r Repository) createSchemas(ctx context.Context, ui model.UI) error {
+	schemas := map[string]any{
+		schemaIndex:    &apiv1.DataV1Index{},
+		schemaHistory:  &apiv1.DataV1HistoryPage{},
+		schemaIncident: &apiv1.DataV1Incident{},
+	}
+
+	for name, v := range schemas {
+		reflector := jsonschema.Reflector{}
+		schema := reflector.Reflect(v)
+		schema.ID = jsonschema.ID(conventions.DataSchemaURL(ui.Settings.URL, name))
+
+		err := r.writeJSON(ctx, conventions.DataSchemaFilePath(r.outPath, name), schema)
+		if err != nil {
+			return fmt.Errorf("could not write %q schema: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (r Repository) createIndex(ctx context.Context, ui model.UI) error {
+	index := apiv1.DataV1Index{
+		Version:     apiv1.DataVersionV1,
+		Name:        ui.Settings.Name,
+		URL:         ui.Settings.URL,
+		GeneratedAt: r.timeNow().UTC(),
+		Status:      mapStatus(ui.Status),
+		Stats: apiv1.DataV1Stats{
+			TotalSystems:           ui.Stats.TotalSystems,
+			TotalIncidents:         ui.Stats.TotalIRs,
+			TotalMinorIncidents:    ui.Stats.TotalMinorIRs,
+			TotalMajorIncidents:    ui.Stats.TotalMajorIRs,
+			TotalCriticalIncidents: ui.Stats.TotalCriticalIRs,
+			TotalOpenIncidents:     ui.Stats.TotalOpenIRs,
+			MTTRSeconds:            ui.Stats.MTTR.Seconds(),
+		},
+		Systems:       []apiv1.DataV1System{},
+		OpenIncidents: []apiv1.DataV1IncidentRef{},
+		HistoryURL:    conventions.DataIRHistoryURL(ui.Settings.URL, 0),
+	}
+
+	for _, s := range ui.SystemDetails {
+		index.Systems = append(index.Systems, apiv1.DataV1System{
+			ID:          s.System.ID,
+			Name:        s.System.Name,
+			Description: s.System.Description,
+			Status:      mapStatus(s.Status),
+			Stats: apiv1.DataV1SystemStats{
+				TotalIncidents: s.Stats.TotalIRs,
+				MTTRSeconds:    s.Stats.MTTR.Seconds(),
+			},
+		})
+	}
+
+	for _, ir := range ui.OpenedIRs {
+		index.OpenIncidents = append(index.OpenIncidents, mapIncidentRef(ui, ir))
+	}
+
+	return r.writeJSON(ctx, conventions.DataIndexFilePath(r.outPath), index)
+}
+
+func (r Repository) createHistory(ctx context.Context, ui model.UI) error {
+	totalPages := (len(ui.History) + r.historyItemsPerPage - 1) / r.historyItemsPerPage
+	if totalPages == 0 {
+		totalPages = 1 // We always want at least one page.
+	}
+
+	for page := 0; page < totalPages; page++ {
+		start := page * r.historyItemsPerPage
+		end := min(start+r.historyItemsPerPage, len(ui.History))
+
+		hp := apiv1.DataV1HistoryPage{
+			Version:    apiv1.DataVersionV1,
+			Page:       page,
+			TotalPages: totalPages,
+			Incidents:  []apiv1.DataV1IncidentRef{},
+		}
+		if page > 0 {
+			hp.PrevURL = conventions.DataIRHistoryURL(ui.Settings.URL, page-1)
+		}
+		if page < totalPages-1 {
+			hp.NextURL = conventions.DataIRHistoryURL(ui.Settings.URL, page+1)
+		}
+
+		for _, ir := range ui.History[start:end] {
+			hp.Incidents = append(hp.Incidents, mapIncidentRef(ui, ir))
+		}
+
+		err := r.writeJSON(ctx, conventions.DataIRHistoryFilePath(r.outPath, page), hp)
+		if err != nil {
+			return fmt.Errorf("could not write history page %d: %w", page, err)
+		}
+	}
+
+	return nil
+}
+
+func (r Repository) createIncidents(ctx context.Context, ui model.UI) error {
+	for _, ir := range ui.History {
+		inc := apiv1.DataV1Incident{
+			Version:         apiv1.DataVersionV1,
+			ID:              ir.ID,
+			Name:            ir.Name,
+			Impact:          string(ir.Impact),
+			SystemIDs:       ir.SystemIDs,
+			Start:           ir.Start.UTC(),
+			End:             mapEnd(ir.End),
+			DurationSeconds: ir.Duration.Seconds(),
+			HTMLURL:         conventions.IRDetailURL(ui.Settings.URL, ir.ID),
+			Timeline:        []apiv1.DataV1IncidentTimeline{},
+		}
+		if inc.SystemIDs == nil {
+			inc.SystemIDs = []string{}
+		}
+
+		for _, e := range ir.Timeline {
+			inc.Timeline = append(inc.Timeline, apiv1.DataV1IncidentTimeline{
+				TS:          e.TS.UTC(),
+				Kind:        string(e.Kind),
+				Description: e.Description,
+				PrivateNote: e.PrivateNote,
+			})
+		}
+
+		err := r.writeJSON(ctx, conventions.DataIRFilePath(r.outPath, ir.ID), inc)
+		if err != nil {
+			return fmt.Errorf("could not write %q incident: %w", ir.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (r Repository) writeJSON(ctx context.Context, path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not marshal JSON: %w", err)
+	}
+
+	return r.fileManager.WriteFile(ctx, path, data)
+}
+
+func mapStatus(s model.SystemStatus) apiv1.DataV1Status {
+	ids := s.OpenIRIDs
+	if ids == nil {
+		ids = []string{}
+	}
+
+	return apiv1.DataV1Status{
+		Operational:     s.Operational,
+		Impact:          string(s.Impact),
+		OpenIncidentIDs: ids,
+	}
+}
+
+func mapIncidentRef(ui model.UI, ir *model.IncidentReport) apiv1.DataV1IncidentRef {
+	systemIDs := ir.SystemIDs
+	if systemIDs == nil {
+		systemIDs = []string{}
+	}
+
+	return apiv1.DataV1IncidentRef{
+		ID:        ir.ID,
+		Name:      ir.Name,
+		Impact:    string(ir.Impact),
+		SystemIDs: systemIDs,
+		Start:     ir.Start.UTC(),
+		End:       mapEnd(ir.End),
+		URL:       conventions.DataIRURL(ui.Settings.URL, ir.ID),
+	}
+}
+
+func mapEnd(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	t = t.UTC()
+	return &t
+}
@@ -0,0 +1,278 @@
+package jsondata_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slok/stactus/internal/model"
+	"github.com/slok/stactus/internal/storage/jsondata"
+	utilfs "github.com/slok/stactus/internal/util/fs"
+)
+
+func TestRepositoryCreateData(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "2024-10-01T10:00:00Z")
+
+	ir1 := &model.IncidentReport{
+		ID:        "ir1",
+		Name:      "IR 1",
+		Impact:    model.IncidentImpactMajor,
+		SystemIDs: []string{"s1"},
+		Start:     t0.Add(-1 * time.Hour),
+		Timeline: []model.IncidentReportEvent{
+			{TS: t0.Add(-1 * time.Hour), Kind: model.IncidentUpdateKindInvestigating, Description: "Something **bad**"},
+		},
+	}
+	ir2 := &model.IncidentReport{
+		ID:        "ir2",
+		Name:      "IR 2",
+		Impact:    model.IncidentImpactMinor,
+		SystemIDs: []string{"s1", "s2"},
+		Start:     t0.Add(-48 * time.Hour),
+		End:       t0.Add(-47 * time.Hour),
+		Duration:  1 * time.Hour,
+		Timeline: []model.IncidentReportEvent{
+			{TS: t0.Add(-47 * time.Hour), Kind: model.IncidentUpdateKindResolved, Description: "Fixed"},
+			{TS: t0.Add(-48 * time.Hour), Kind: model.IncidentUpdateKindInvestigating, Description: "Looking"},
+		},
+	}
+
+	tests := map[string]struct {
+		historyItemsPerPage int
+		ui                  func() model.UI
+		expFiles            map[string]string
+		expContains         map[string][]string
+		expErr              bool
+	}{
+		"The UI data should be exported as JSON documents.": {
+			historyItemsPerPage: 1,
+			ui: func() model.UI {
+				return model.UI{
+					Settings: model.StatusPageSettings{Name: "Test", URL: "https://status.slok.dev"},
+					Status:   model.SystemStatus{Impact: model.IncidentImpactMajor, OpenIRIDs: []string{"ir1"}},
+					Stats: model.UIStats{
+						TotalSystems:  2,
+						TotalIRs:      2,
+						TotalMinorIRs: 1,
+						TotalMajorIRs: 1,
+						TotalOpenIRs:  1,
+						MTTR:          1 * time.Hour,
+					},
+					SystemDetails: []model.SystemDetails{
+						{
+							System: model.System{ID: "s1", Name: "System 1", Description: "Desc 1"},
+							IRs:    []*model.IncidentReport{ir1, ir2},
+							Status: model.SystemStatus{Impact: model.IncidentImpactMajor, OpenIRIDs: []string{"ir1"}},
+							Stats:  model.SystemStats{TotalIRs: 2, MTTR: 1 * time.Hour},
+						},
+						{
+							System: model.System{ID: "s2", Name: "System 2"},
+							IRs:    []*model.IncidentReport{ir2},
+							Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
+							Stats:  model.SystemStats{TotalIRs: 1, MTTR: 1 * time.Hour},
+						},
+					},
+					History:   []*model.IncidentReport{ir1, ir2},
+					OpenedIRs: []*model.IncidentReport{ir1},
+				}
+			},
+			expFiles: map[string]string{
+				"test/api/v1/index.json": `{
+  "version": "data/v1",
+  "name": "Test",
+  "url": "https://status.slok.dev",
+  "generatedAt": "2024-10-01T10:00:00Z",
+  "status": {
+    "operational": false,
+    "impact": "major",
+    "openIncidentIDs": [
+      "ir1"
+    ]
+  },
+  "stats": {
+    "totalSystems": 2,
+    "totalIncidents": 2,
+    "totalMinorIncidents": 1,
+    "totalMajorIncidents": 1,
+    "totalCriticalIncidents": 0,
+    "totalOpenIncidents": 1,
+    "mttrSeconds": 3600
+  },
+  "systems": [
+    {
+      "id": "s1",
+      "name": "System 1",
+      "description": "Desc 1",
+      "status": {
+        "operational": false,
+        "impact": "major",
+        "openIncidentIDs": [
+          "ir1"
+        ]
+      },
+      "stats": {
+        "totalIncidents": 2,
+        "mttrSeconds": 3600
+      }
+    },
+    {
+      "id": "s2",
+      "name": "System 2",
+      "status": {
+        "operational": true,
+        "impact": "none",
+        "openIncidentIDs": []
+      },
+      "stats": {
+        "totalIncidents": 1,
+        "mttrSeconds": 3600
+      }
+    }
+  ],
+  "openIncidents": [
+    {
+      "id": "ir1",
+      "name": "IR 1",
+      "impact": "major",
+      "systemIDs": [
+        "s1"
+      ],
+      "start": "2024-10-01T09:00:00Z",
+      "url": "https://status.slok.dev/api/v1/ir/ir1.json"
+    }
+  ],
+  "historyURL": "https://status.slok.dev/api/v1/history/0.json"
+}`,
+				"test/api/v1/history/0.json": `{
+  "version": "data/v1",
+  "page": 0,
+  "totalPages": 2,
+  "nextURL": "https://status.slok.dev/api/v1/history/1.json",
+  "incidents": [
+    {
+      "id": "ir1",
+      "name": "IR 1",
+      "impact": "major",
+      "systemIDs": [
+        "s1"
+      ],
+      "start": "2024-10-01T09:00:00Z",
+      "url": "https://status.slok.dev/api/v1/ir/ir1.json"
+    }
+  ]
+}`,
+				"test/api/v1/history/1.json": `{
+  "version": "data/v1",
+  "page": 1,
+  "totalPages": 2,
+  "prevURL": "https://status.slok.dev/api/v1/history/0.json",
+  "incidents": [
+    {
+      "id": "ir2",
+      "name": "IR 2",
+      "impact": "minor",
+      "systemIDs": [
+        "s1",
+        "s2"
+      ],
+      "start": "2024-09-29T10:00:00Z",
+      "end": "2024-09-29T11:00:00Z",
+      "url": "https://status.slok.dev/api/v1/ir/ir2.json"
+    }
+  ]
+}`,
+				"test/api/v1/ir/ir2.json": `{
+  "version": "data/v1",
+  "id": "ir2",
+  "name": "IR 2",
+  "impact": "minor",
+  "systemIDs": [
+    "s1",
+    "s2"
+  ],
+  "start": "2024-09-29T10:00:00Z",
+  "end": "2024-09-29T11:00:00Z",
+  "durationSeconds": 3600,
+  "htmlURL": "https://status.slok.dev/ir/ir2",
+  "timeline": [
+    {
+      "ts": "2024-09-29T11:00:00Z",
+      "kind": "resolved",
+      "description": "Fixed"
+    },
+    {
+      "ts": "2024-09-29T10:00:00Z",
+      "kind": "investigating",
+      "description": "Looking"
+    }
+  ]
+}`,
+			},
+			expContains: map[string][]string{
+				"test/api/v1/ir/ir1.json": {
+					`"description": "Something **bad**"`,
+				},
+				"test/api/v1/schema/index.json": {
+					`"$id": "https://status.slok.dev/api/v1/schema/index.json",`,
+					`"$ref": "#/$defs/DataV1Index",`,
+				},
+				"test/api/v1/schema/history.json": {
+					`"$id": "https://status.slok.dev/api/v1/schema/history.json",`,
+					`"$ref": "#/$defs/DataV1HistoryPage",`,
+				},
+				"test/api/v1/schema/incident.json": {
+					`"$id": "https://status.slok.dev/api/v1/schema/incident.json",`,
+					`"$ref": "#/$defs/DataV1Incident",`,
+				},
+			},
+		},
+
+		"Without incidents, there should be an empty history page.": {
+			ui: func() model.UI {
+				return model.UI{
+					Settings: model.StatusPageSettings{Name: "Test", URL: "https://status.slok.dev"},
+					Status:   model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
+				}
+			},
+			expFiles: map[string]string{
+				"test/api/v1/history/0.json": `{
+  "version": "data/v1",
+  "page": 0,
+  "totalPages": 1,
+  "incidents": []
+}`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			fsm := utilfs.NewTestFileManager()
+			repo, err := jsondata.NewFSRepository(jsondata.RepositoryConfig{
+				FileManager:         fsm,
+				OutPath:             "test",
+				HistoryItemsPerPage: test.historyItemsPerPage,
+				TimeNow:             func() time.Time { return t0 },
+			})
+			require.NoError(err)
+
+			err = repo.CreateData(context.TODO(), test.ui())
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				for k, v := range test.expFiles {
+					fsm.AssertEqual(t, k, v)
+				}
+				for k, v := range test.expContains {
+					fsm.AssertContains(t, k, v)
+				}
+			}
+		})
+	}
+}
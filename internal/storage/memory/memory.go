@@ -11,13 +11,15 @@ type Repository struct {
 	settings        model.StatusPageSettings
 	systems         []model.System
 	incidentReports []model.IncidentReport
+	pages           []model.Page
 }
 
-func NewRepository(systems []model.System, settings model.StatusPageSettings, incidentReports []model.IncidentReport) Repository {
+func NewRepository(systems []model.System, settings model.StatusPageSettings, incidentReports []model.IncidentReport, pages []model.Page) Repository {
 	return Repository{
 		settings:        settings,
 		systems:         slices.Clone(systems),
 		incidentReports: slices.Clone(incidentReports),
+		pages:           slices.Clone(pages),
 	}
 }
 
@@ -32,3 +34,7 @@ func (r Repository) ListAllSystems(ctx context.Context) ([]model.System, error)
 func (r Repository) ListAllIncidentReports(ctx context.Context) ([]model.IncidentReport, error) {
 	return slices.Clone(r.incidentReports), nil
 }
+
+func (r Repository) ListAllPages(ctx context.Context) ([]model.Page, error) {
+	return slices.Clone(r.pages), nil
+}
@@ -22,7 +22,7 @@ func TestRepositoryGetStatusPageSettings(t *testing.T) {
 				r := memory.NewRepository(nil, model.StatusPageSettings{
 					Name: "Test name",
 					URL:  "https://soemthing.something3213213.io",
-				}, nil)
+				}, nil, nil)
 				return r
 			},
 			expSettings: model.StatusPageSettings{
@@ -61,7 +61,7 @@ func TestRepositoryListAllSystems(t *testing.T) {
 					{ID: "test3", Name: "Test 3", Description: "something 3"},
 					{ID: "test1", Name: "Test 1", Description: "something 1"},
 					{ID: "test4", Name: "Test 4", Description: "something 4"},
-				}, model.StatusPageSettings{}, nil)
+				}, model.StatusPageSettings{}, nil, nil)
 				return r
 			},
 			expSystems: []model.System{
@@ -107,7 +107,7 @@ func TestRepositoryListAllIncidentReports(t *testing.T) {
 					}},
 					{ID: "test4", Name: "Test 4", Start: t0.Add(42 * time.Minute)},
 					{ID: "test3", Name: "Test 3", Start: t0.Add(142 * time.Minute)},
-				})
+				}, nil)
 				return r
 			},
 			expIRs: []model.IncidentReport{
@@ -137,3 +137,40 @@ func TestRepositoryListAllIncidentReports(t *testing.T) {
 		})
 	}
 }
+
+func TestRepositoryListAllPages(t *testing.T) {
+	tests := map[string]struct {
+		repo     func() memory.Repository
+		expPages []model.Page
+		expErr   bool
+	}{
+		"Having multiple pages should be returned.": {
+			repo: func() memory.Repository {
+				r := memory.NewRepository(nil, model.StatusPageSettings{}, nil, []model.Page{
+					{Slug: "test2", Title: "Test 2", NavOrder: 2, Content: "something 2"},
+					{Slug: "test1", Title: "Test 1", NavOrder: 1, Content: "something 1"},
+				})
+				return r
+			},
+			expPages: []model.Page{
+				{Slug: "test2", Title: "Test 2", NavOrder: 2, Content: "something 2"},
+				{Slug: "test1", Title: "Test 1", NavOrder: 1, Content: "something 1"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			r := test.repo()
+			gotPages, err := r.ListAllPages(context.TODO())
+
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				assert.Equal(test.expPages, gotPages)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package ogimage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/slok/stactus/internal/conventions"
+	"github.com/slok/stactus/internal/model"
+	utilfs "github.com/slok/stactus/internal/util/fs"
+)
+
+type RepositoryConfig struct {
+	FileManager utilfs.FileManager
+	OutPath     string
+}
+
+func (c *RepositoryConfig) defaults() error {
+	if c.FileManager == nil {
+		c.FileManager = utilfs.StdFileManager
+	}
+
+	if c.OutPath == "" {
+		return fmt.Errorf("out path is required")
+	}
+	c.OutPath = filepath.Clean(c.OutPath)
+
+	return nil
+}
+
+// Repository knows how to create OpenGraph preview images (PNG cards) of the status page
+// and its incidents, these are used by Slack, social networks... when sharing the links.
+// The images are drawn in pure Go with embedded fonts, so it works offline.
+type Repository struct {
+	fileManager utilfs.FileManager
+	outPath     string
+
+	brandFace font.Face
+	titleFace font.Face
+	textFace  font.Face
+}
+
+func NewFSRepository(config RepositoryConfig) (*Repository, error) {
+	err := config.defaults()
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	regular, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		return nil, fmt.Errorf("could not load regular font: %w", err)
+	}
+
+	bold, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		return nil, fmt.Errorf("could not load bold font: %w", err)
+	}
+
+	brandFace, err := newFace(bold, 40)
+	if err != nil {
+		return nil, err
+	}
+
+	titleFace, err := newFace(bold, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	textFace, err := newFace(regular, 34)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repository{
+		fileManager: config.FileManager,
+		outPath:     config.OutPath,
+		brandFace:   brandFace,
+		titleFace:   titleFace,
+		textFace:    textFace,
+	}, nil
+}
+
+func newFace(f *opentype.Font, size float64) (font.Face, error) {
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, fmt.Errorf("could not create font face: %w", err)
+	}
+
+	return face, nil
+}
+
+func (r Repository) CreateOGImages(ctx context.Context, ui model.UI) error {
+	// Index.
+	c := card{
+		Brand: ui.Settings.Name + " status",
+		Title: "All systems operational",
+		Color: statusColors[statusOK],
+		URL:   ui.Settings.URL,
+	}
+	if !ui.Status.Operational {
+		c.Title = fmt.Sprintf("%d ongoing incidents", len(ui.OpenedIRs))
+		if len(ui.OpenedIRs) == 1 {
+			c.Title = ui.OpenedIRs[0].Name
+		}
+		c.Status = fmt.Sprintf("Ongoing — %s impact", impactName(ui.Status.Impact))
+		c.Color = statusColors[string(ui.Status.Impact)]
+	}
+
+	err := r.writeCard(ctx, c, conventions.IndexOGImageFilePath(r.outPath))
+	if err != nil {
+		return fmt.Errorf("could not write index image: %w", err)
+	}
+
+	// Incidents.
+	for _, ir := range ui.History {
+		state := "Resolved"
+		if ir.End.IsZero() {
+			state = "Ongoing"
+		}
+
+		c := card{
+			Brand:  ui.Settings.Name + " status",
+			Title:  ir.Name,
+			Status: fmt.Sprintf("%s — %s impact", state, impactName(ir.Impact)),
+			Color:  statusColors[string(ir.Impact)],
+			URL:    conventions.IRDetailURL(ui.Settings.URL, ir.ID),
+		}
+
+		err := r.writeCard(ctx, c, conventions.IROGImageFilePath(r.outPath, ir.ID))
+		if err != nil {
+			return fmt.Errorf("could not write %q incident image: %w", ir.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (r Repository) writeCard(ctx context.Context, c card, path string) error {
+	img, err := r.renderCard(c)
+	if err != nil {
+		return fmt.Errorf("could not render image: %w", err)
+	}
+
+	return r.fileManager.WriteFile(ctx, path, img)
+}
+
+const (
+	cardWidth     = 1200
+	cardHeight    = 630
+	cardPadding   = 80
+	cardBarHeight = 24
+	titleMaxLines = 3
+)
+
+const statusOK = "ok"
+
+var statusColors = map[string]color.RGBA{
+	statusOK:                             {R: 0x28, G: 0xa7, B: 0x45, A: 0xff},
+	string(model.IncidentImpactNone):     {R: 0x7f, G: 0x7f, B: 0x7f, A: 0xff},
+	string(model.IncidentImpactMinor):    {R: 0xdb, G: 0xab, B: 0x09, A: 0xff},
+	string(model.IncidentImpactMajor):    {R: 0xe3, G: 0x62, B: 0x09, A: 0xff},
+	string(model.IncidentImpactCritical): {R: 0xdc, G: 0x35, B: 0x45, A: 0xff},
+}
+
+var (
+	colorBackground = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	colorText       = color.RGBA{R: 0x1f, G: 0x23, B: 0x28, A: 0xff}
+	colorMuted      = color.RGBA{R: 0x65, G: 0x6d, B: 0x76, A: 0xff}
+)
+
+type card struct {
+	Brand  string
+	Title  string
+	Status string // Optional.
+	Color  color.RGBA
+	URL    string
+}
+
+func (r Repository) renderCard(c card) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, cardWidth, cardHeight))
+	draw.Draw(img, img.Bounds(), image.NewUniform(colorBackground), image.Point{}, draw.Src)
+
+	// Status color bars.
+	draw.Draw(img, image.Rect(0, 0, cardWidth, cardBarHeight), image.NewUniform(c.Color), image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(0, cardHeight-cardBarHeight/2, cardWidth, cardHeight), image.NewUniform(c.Color), image.Point{}, draw.Src)
+
+	maxWidth := fixed.I(cardWidth - 2*cardPadding)
+
+	// Brand.
+	y := cardBarHeight + cardPadding + r.brandFace.Metrics().Ascent.Ceil()
+	r.drawText(img, r.brandFace, colorMuted, cardPadding, y, truncate(r.brandFace, c.Brand, maxWidth))
+
+	// Title.
+	lineHeight := r.titleFace.Metrics().Height.Ceil() + 8
+	y += 40
+	for _, line := range wrap(r.titleFace, c.Title, maxWidth, titleMaxLines) {
+		y += lineHeight
+		r.drawText(img, r.titleFace, colorText, cardPadding, y, line)
+	}
+
+	// Status pill and URL on the bottom.
+	bottom := cardHeight - cardBarHeight - cardPadding + 20
+	if c.Status != "" {
+		dotSize := 28
+		dotY := bottom - r.textFace.Metrics().Ascent.Ceil()/2 - dotSize/2
+		draw.Draw(img, image.Rect(cardPadding, dotY, cardPadding+dotSize, dotY+dotSize), image.NewUniform(c.Color), image.Point{}, draw.Src)
+		r.drawText(img, r.textFace, colorText, cardPadding+dotSize+16, bottom, c.Status)
+	}
+
+	if c.URL != "" {
+		u := strings.TrimPrefix(strings.TrimPrefix(c.URL, "https://"), "http://")
+		u = truncate(r.textFace, u, fixed.I(cardWidth/2-cardPadding))
+		w := font.MeasureString(r.textFace, u).Ceil()
+		r.drawText(img, r.textFace, colorMuted, cardWidth-cardPadding-w, bottom, u)
+	}
+
+	var b bytes.Buffer
+	err := png.Encode(&b, img)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+func (r Repository) drawText(img draw.Image, face font.Face, c color.Color, x, y int, text string) {
+	d := font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(c),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(text)
+}
+
+// wrap splits the text in lines that fit in the max width, if the text doesn't fit
+// in the max lines, the last line will be truncated.
+func wrap(face font.Face, text string, maxWidth fixed.Int26_6, maxLines int) []string {
+	lines := []string{}
+	current := ""
+	words := strings.Fields(text)
+	for i, word := range words {
+		candidate := strings.TrimSpace(current + " " + word)
+		if current == "" || font.MeasureString(face, candidate) <= maxWidth {
+			current = candidate
+			continue
+		}
+
+		lines = append(lines, current)
+		current = word
+		if len(lines) == maxLines-1 {
+			current = strings.Join(words[i:], " ")
+			break
+		}
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+
+	for i, l := range lines {
+		lines[i] = truncate(face, l, maxWidth)
+	}
+
+	return lines
+}
+
+// truncate cuts the text with an ellipsis if it doesn't fit in the max width.
+func truncate(face font.Face, text string, maxWidth fixed.Int26_6) string {
+	if font.MeasureString(face, text) <= maxWidth {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		t := strings.TrimSpace(string(runes)) + "…"
+		if font.MeasureString(face, t) <= maxWidth {
+			return t
+		}
+	}
+
+	return ""
+}
+
+func impactName(i model.IncidentImpact) string {
+	s := string(i)
+	if s == "" {
+		return ""
+	}
+
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,108 @@
+package ogimage_test
+
+import (
+	"bytes"
+	"context"
+	"image/color"
+	"image/png"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slok/stactus/internal/model"
+	"github.com/slok/stactus/internal/storage/ogimage"
+	utilfs "github.com/slok/stactus/internal/util/fs"
+)
+
+func TestRepositoryCreateOGImages(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "2024-10-01T10:00:00Z")
+
+	var (
+		colorOK       = color.RGBA{R: 0x28, G: 0xa7, B: 0x45, A: 0xff}
+		colorMinor    = color.RGBA{R: 0xdb, G: 0xab, B: 0x09, A: 0xff}
+		colorCritical = color.RGBA{R: 0xdc, G: 0x35, B: 0x45, A: 0xff}
+	)
+
+	tests := map[string]struct {
+		ui        model.UI
+		expImages map[string]color.RGBA // Path -> status color.
+		expErr    bool
+	}{
+		"Operational status page should render the index image with the operational color.": {
+			ui: model.UI{
+				Settings: model.StatusPageSettings{Name: "Test", URL: "https://status.test.dev"},
+				Status:   model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
+			},
+			expImages: map[string]color.RGBA{
+				"test/og/index.png": colorOK,
+			},
+		},
+
+		"Incidents should render an image per incident with the impact color.": {
+			ui: func() model.UI {
+				ir1 := &model.IncidentReport{
+					ID:     "ir-1",
+					Name:   "Something is very broken and this title is long enough to be wrapped in multiple lines, and even truncated at the end of the last line",
+					Start:  t0,
+					Impact: model.IncidentImpactCritical,
+				}
+				ir2 := &model.IncidentReport{
+					ID:     "ir-2",
+					Name:   "Something was slow",
+					Start:  t0.Add(-2 * time.Hour),
+					End:    t0.Add(-1 * time.Hour),
+					Impact: model.IncidentImpactMinor,
+				}
+
+				return model.UI{
+					Settings:  model.StatusPageSettings{Name: "Test", URL: "https://status.test.dev"},
+					Status:    model.SystemStatus{Impact: model.IncidentImpactCritical, OpenIRIDs: []string{"ir-1"}},
+					OpenedIRs: []*model.IncidentReport{ir1},
+					History:   []*model.IncidentReport{ir1, ir2},
+				}
+			}(),
+			expImages: map[string]color.RGBA{
+				"test/og/index.png":   colorCritical,
+				"test/og/ir/ir-1.png": colorCritical,
+				"test/og/ir/ir-2.png": colorMinor,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			fs := fstest.MapFS{}
+			repo, err := ogimage.NewFSRepository(ogimage.RepositoryConfig{
+				FileManager: utilfs.NewMemoryFSFileManager(fs),
+				OutPath:     "./test",
+			})
+			require.NoError(err)
+
+			err = repo.CreateOGImages(context.TODO(), test.ui)
+			if test.expErr {
+				assert.Error(err)
+				return
+			}
+			require.NoError(err)
+
+			assert.Len(fs, len(test.expImages))
+			for path, expColor := range test.expImages {
+				f, ok := fs[path]
+				require.True(ok, "missing %q image", path)
+
+				img, err := png.Decode(bytes.NewReader(f.Data))
+				require.NoError(err)
+
+				assert.Equal(1200, img.Bounds().Dx())
+				assert.Equal(630, img.Bounds().Dy())
+				assert.Equal(expColor, color.RGBAModel.Convert(img.At(0, 0)), "status color of %q", path)
+			}
+		})
+	}
+}
@@ -7,11 +7,13 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/expfmt"
 
 	"github.com/slok/stactus/internal/conventions"
+	"github.com/slok/stactus/internal/info"
 	"github.com/slok/stactus/internal/model"
 	utilfs "github.com/slok/stactus/internal/util/fs"
 )
@@ -19,6 +21,8 @@ import (
 type RepositoryConfig struct {
 	FileManager     utilfs.FileManager
 	MetricsFilePath string
+	Version         string
+	TimeNow         func() time.Time
 }
 
 func (c *RepositoryConfig) defaults() error {
@@ -36,12 +40,22 @@ func (c *RepositoryConfig) defaults() error {
 		return fmt.Errorf("metrics fule must end with 'metrics' path")
 	}
 
+	if c.Version == "" {
+		c.Version = info.Version
+	}
+
+	if c.TimeNow == nil {
+		c.TimeNow = func() time.Time { return time.Now().UTC() }
+	}
+
 	return nil
 }
 
 type Repository struct {
 	fileManager utilfs.FileManager
 	filePath    string
+	version     string
+	timeNow     func() time.Time
 }
 
 func NewFSRepository(config RepositoryConfig) (*Repository, error) {
@@ -53,6 +67,8 @@ func NewFSRepository(config RepositoryConfig) (*Repository, error) {
 	return &Repository{
 		fileManager: config.FileManager,
 		filePath:    config.MetricsFilePath,
+		version:     config.Version,
+		timeNow:     config.TimeNow,
 	}, nil
 }
 
@@ -61,7 +77,7 @@ func (r Repository) CreatePromMetrics(ctx context.Context, ui model.UI) error {
 
 	metrics, err := r.genMetrics(ui, reg)
 	if err != nil {
-		return fmt.Errorf("could not create")
+		return fmt.Errorf("could not create metrics: %w", err)
 	}
 
 	err = r.fileManager.WriteFile(ctx, r.filePath, []byte(metrics))
@@ -73,10 +89,16 @@ func (r Repository) CreatePromMetrics(ctx context.Context, ui model.UI) error {
 }
 
 func (r Repository) genMetrics(ui model.UI, reg *prometheus.Registry) (string, error) {
-	prefix := "stactus"
-	constLabels := prometheus.Labels(map[string]string{
-		"status_page": ui.Settings.Name,
-	})
+	prefix := ui.Settings.Metrics.Namespace
+	if prefix == "" {
+		prefix = "stactus"
+	}
+	now := r.timeNow()
+	constLabels := prometheus.Labels(map[string]string{})
+	for k, v := range ui.Settings.Metrics.Labels {
+		constLabels[k] = v
+	}
+	constLabels[model.MetricsReservedLabel] = ui.Settings.Name
 
 	// Create metrics.
 	mttr := prometheus.NewGaugeVec(prometheus.GaugeOpts{
@@ -92,8 +114,8 @@ func (r Repository) genMetrics(ui model.UI, reg *prometheus.Registry) (string, e
 		Name:        "all_systems_status",
 		Help:        "Tells if all systems are operational or not.",
 		ConstLabels: constLabels,
-	}, []string{"status_ok"})
-	allOK := len(ui.OpenedIRs) == 0
+	}, []string{model.MetricsLabelStatusOK})
+	allOK := ui.Status.Operational
 	allSystemsOperational.WithLabelValues(strconv.FormatBool(allOK)).Set(1)
 
 	systemsStatus := prometheus.NewGaugeVec(prometheus.GaugeOpts{
@@ -101,25 +123,9 @@ func (r Repository) genMetrics(ui model.UI, reg *prometheus.Registry) (string, e
 		Name:        "system_status",
 		Help:        "Tells Systems are operational or not.",
 		ConstLabels: constLabels,
-	}, []string{"id", "name", "status_ok", "impact"})
+	}, []string{model.MetricsLabelID, model.MetricsLabelName, model.MetricsLabelStatusOK, model.MetricsLabelImpact})
 	for _, s := range ui.SystemDetails {
-		systemOK := true
-		impact := model.IncidentImpactNone
-		for _, ir := range s.IRs {
-			if !ir.End.IsZero() {
-				continue
-			}
-			systemOK = false
-			switch {
-			case impact == model.IncidentImpactNone:
-				impact = ir.Impact
-			case ir.Impact == model.IncidentImpactCritical:
-				impact = ir.Impact
-			case ir.Impact == model.IncidentImpactMajor && impact == model.IncidentImpactMinor:
-				impact = ir.Impact
-			}
-		}
-		systemsStatus.WithLabelValues(s.System.ID, s.System.Name, strconv.FormatBool(systemOK), string(impact)).Set(1)
+		systemsStatus.WithLabelValues(s.System.ID, s.System.Name, strconv.FormatBool(s.Status.Operational), string(s.Status.Impact)).Set(1)
 	}
 
 	openIRs := prometheus.NewGaugeVec(prometheus.GaugeOpts{
@@ -127,26 +133,123 @@ func (r Repository) genMetrics(ui model.UI, reg *prometheus.Registry) (string, e
 		Name:        "open_incident",
 		Help:        "The details of open (not resolved) incidents.",
 		ConstLabels: constLabels,
-	}, []string{"id", "impact"})
+	}, []string{model.MetricsLabelID, model.MetricsLabelImpact})
 	for _, ir := range ui.OpenedIRs {
 		openIRs.WithLabelValues(ir.ID, string(ir.Impact)).Set(1)
 	}
 
+	openIRsDuration := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace:   prefix,
+		Name:        "open_incident_duration_seconds",
+		Help:        "The duration of the open (not resolved) incidents at generation time.",
+		ConstLabels: constLabels,
+	}, []string{model.MetricsLabelID, model.MetricsLabelImpact})
+	openIRsLastUpdateAge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace:   prefix,
+		Name:        "open_incident_last_update_age_seconds",
+		Help:        "The time since the last update of the open (not resolved) incidents at generation time.",
+		ConstLabels: constLabels,
+	}, []string{model.MetricsLabelID, model.MetricsLabelImpact})
+	for _, ir := range ui.OpenedIRs {
+		openIRsDuration.WithLabelValues(ir.ID, string(ir.Impact)).Set(now.Sub(ir.Start).Seconds())
+		if len(ir.Timeline) > 0 {
+			openIRsLastUpdateAge.WithLabelValues(ir.ID, string(ir.Impact)).Set(now.Sub(ir.Timeline[0].TS).Seconds()) // Latest.
+		}
+	}
+
+	// Gauges instead of counters, the history is rebuilt on each generation and incidents can be removed.
+	irsCount := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace:   prefix,
+		Name:        "incidents",
+		Help:        "The number of incidents.",
+		ConstLabels: constLabels,
+	}, []string{model.MetricsLabelImpact})
+	for _, ir := range ui.History {
+		irsCount.WithLabelValues(string(ir.Impact)).Inc()
+	}
+
+	systemIRsCount := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace:   prefix,
+		Name:        "system_incidents",
+		Help:        "The number of incidents per system.",
+		ConstLabels: constLabels,
+	}, []string{model.MetricsLabelID, model.MetricsLabelImpact})
+	systemLastIRStart := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace:   prefix,
+		Name:        "system_last_incident_start_timestamp_seconds",
+		Help:        "The start timestamp of the latest incident of the system.",
+		ConstLabels: constLabels,
+	}, []string{model.MetricsLabelID})
+	systemLastIREnd := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace:   prefix,
+		Name:        "system_last_incident_end_timestamp_seconds",
+		Help:        "The end timestamp of the latest incident of the system, missing if the incident is not resolved.",
+		ConstLabels: constLabels,
+	}, []string{model.MetricsLabelID})
+	for _, s := range ui.SystemDetails {
+		for _, ir := range s.IRs {
+			systemIRsCount.WithLabelValues(s.System.ID, string(ir.Impact)).Inc()
+		}
+
+		if s.LatestIR == nil {
+			continue
+		}
+		systemLastIRStart.WithLabelValues(s.System.ID).Set(timestampSeconds(s.LatestIR.Start))
+		if !s.LatestIR.End.IsZero() {
+			systemLastIREnd.WithLabelValues(s.System.ID).Set(timestampSeconds(s.LatestIR.End))
+		}
+	}
+
+	generated := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace:   prefix,
+		Name:        "generated_timestamp_seconds",
+		Help:        "The timestamp when the status page was generated.",
+		ConstLabels: constLabels,
+	}, []string{})
+	generated.WithLabelValues().Set(timestampSeconds(now))
+
+	buildInfo := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace:   prefix,
+		Name:        "build_info",
+		Help:        "The information of the stactus build that generated the status page.",
+		ConstLabels: constLabels,
+	}, []string{model.MetricsLabelVersion})
+	buildInfo.WithLabelValues(r.version).Set(1)
+
 	// Register metrics.
-	reg.MustRegister(
+	collectors := []prometheus.Collector{
 		openIRs,
+		openIRsDuration,
+		openIRsLastUpdateAge,
 		allSystemsOperational,
 		mttr,
 		systemsStatus,
-	)
+		irsCount,
+		systemIRsCount,
+		systemLastIRStart,
+		systemLastIREnd,
+		generated,
+		buildInfo,
+	}
+	for _, c := range collectors {
+		err := reg.Register(c)
+		if err != nil {
+			return "", fmt.Errorf("could not register metrics: %w", err)
+		}
+	}
 
 	mfs, err := reg.Gather()
 	if err != nil {
 		return "", fmt.Errorf("could not gater metrics: %w", err)
 	}
 
+	format := expfmt.NewFormat(expfmt.TypeTextPlain)
+	if ui.Settings.Metrics.Format == model.MetricsFormatOpenMetrics {
+		format = expfmt.NewFormat(expfmt.TypeOpenMetrics)
+	}
+
 	var b bytes.Buffer
-	enc := expfmt.NewEncoder(&b, expfmt.NewFormat(expfmt.TypeTextPlain))
+	enc := expfmt.NewEncoder(&b, format)
 	for _, mf := range mfs {
 		err := enc.Encode(mf)
 		if err != nil {
@@ -154,5 +257,17 @@ func (r Repository) genMetrics(ui model.UI, reg *prometheus.Registry) (string, e
 		}
 	}
 
+	// OpenMetrics requires an EOF marker.
+	if closer, ok := enc.(expfmt.Closer); ok {
+		err := closer.Close()
+		if err != nil {
+			return "", fmt.Errorf("could not finalize metrics: %w", err)
+		}
+	}
+
 	return b.String(), nil
 }
+
+func timestampSeconds(t time.Time) float64 {
+	return float64(t.UnixNano()) / float64(time.Second)
+}
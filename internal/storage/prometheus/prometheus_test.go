@@ -12,6 +12,10 @@ import (
 )
 
 func TestRepositoryCreatePromMetrics(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "2024-10-01T10:00:00Z")
+	ir1 := &model.IncidentReport{ID: "ir1", SystemIDs: []string{"s1"}, Impact: model.IncidentImpactMajor, Start: t0.Add(-2 * time.Hour)}
+	ir2 := &model.IncidentReport{ID: "ir2", SystemIDs: []string{"s4"}, Impact: model.IncidentImpactMinor, Start: t0.Add(-48 * time.Hour), End: t0.Add(-47 * time.Hour)}
+
 	tests := map[string]struct {
 		ui         func() model.UI
 		expMetrics string
@@ -22,14 +26,17 @@ func TestRepositoryCreatePromMetrics(t *testing.T) {
 				return model.UI{
 					SystemDetails: []model.SystemDetails{
 						{
-							System: model.System{ID: "s1", Name: "System 1"},
+							System:   model.System{ID: "s1", Name: "System 1"},
+							Status:   model.SystemStatus{Impact: model.IncidentImpactMajor, OpenIRIDs: []string{"ir1"}},
+							LatestIR: ir1,
 							IRs: []*model.IncidentReport{
-								{Impact: model.IncidentImpactMinor},
-								{Impact: model.IncidentImpactMajor},
+								ir1,
+								{Impact: model.IncidentImpactMinor, End: t0.Add(-5 * time.Hour)},
 							},
 						},
 						{
 							System: model.System{ID: "s2", Name: "System 2"},
+							Status: model.SystemStatus{Impact: model.IncidentImpactCritical, OpenIRIDs: []string{"test1"}},
 							IRs: []*model.IncidentReport{
 								{Impact: model.IncidentImpactMajor},
 								{Impact: model.IncidentImpactCritical},
@@ -37,14 +44,17 @@ func TestRepositoryCreatePromMetrics(t *testing.T) {
 						},
 						{
 							System: model.System{ID: "s3", Name: "System 3"},
+							Status: model.SystemStatus{Impact: model.IncidentImpactMinor, OpenIRIDs: []string{"test2", "test3"}},
 							IRs: []*model.IncidentReport{
 								{Impact: model.IncidentImpactMinor},
 							},
 						},
 						{
-							System: model.System{ID: "s4", Name: "System 4"},
+							System:   model.System{ID: "s4", Name: "System 4"},
+							Status:   model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
+							LatestIR: ir2,
 							IRs: []*model.IncidentReport{
-								{Impact: model.IncidentImpactMinor, End: time.Now()},
+								ir2,
 							},
 						},
 					},
@@ -54,10 +64,22 @@ func TestRepositoryCreatePromMetrics(t *testing.T) {
 					Stats: model.UIStats{
 						MTTR: 42 * time.Minute,
 					},
+					History: []*model.IncidentReport{
+						{Impact: model.IncidentImpactMinor},
+						{Impact: model.IncidentImpactMajor},
+						{Impact: model.IncidentImpactMinor},
+						{Impact: model.IncidentImpactCritical},
+					},
 					OpenedIRs: []*model.IncidentReport{
-						{ID: "test1", Impact: model.IncidentImpactCritical},
-						{ID: "test2", Impact: model.IncidentImpactMinor},
-						{ID: "test3", Impact: model.IncidentImpactNone},
+						ir1,
+						{ID: "test1", SystemIDs: []string{"s2"}, Impact: model.IncidentImpactCritical, Start: t0.Add(-90 * time.Minute), Timeline: []model.IncidentReportEvent{
+							{TS: t0.Add(-30 * time.Minute)},
+							{TS: t0.Add(-90 * time.Minute)},
+						}},
+						{ID: "test2", SystemIDs: []string{"s3"}, Impact: model.IncidentImpactMinor, Start: t0.Add(-10 * time.Minute), Timeline: []model.IncidentReportEvent{
+							{TS: t0.Add(-10 * time.Minute)},
+						}},
+						{ID: "test3", SystemIDs: []string{"s3"}, Impact: model.IncidentImpactNone, Start: t0.Add(-1 * time.Minute)},
 					},
 				}
 			},
@@ -65,14 +87,51 @@ func TestRepositoryCreatePromMetrics(t *testing.T) {
 # HELP stactus_all_systems_status Tells if all systems are operational or not.
 # TYPE stactus_all_systems_status gauge
 stactus_all_systems_status{status_ok="false",status_page="test-SP"} 1
+# HELP stactus_build_info The information of the stactus build that generated the status page.
+# TYPE stactus_build_info gauge
+stactus_build_info{status_page="test-SP",version="v1.2.3"} 1
+# HELP stactus_generated_timestamp_seconds The timestamp when the status page was generated.
+# TYPE stactus_generated_timestamp_seconds gauge
+stactus_generated_timestamp_seconds{status_page="test-SP"} 1.7277768e+09
 # HELP stactus_incident_mttr_seconds The MTTR based on all the incident history.
 # TYPE stactus_incident_mttr_seconds gauge
 stactus_incident_mttr_seconds{status_page="test-SP"} 2520
+# HELP stactus_incidents The number of incidents.
+# TYPE stactus_incidents gauge
+stactus_incidents{impact="critical",status_page="test-SP"} 1
+stactus_incidents{impact="major",status_page="test-SP"} 1
+stactus_incidents{impact="minor",status_page="test-SP"} 2
 # HELP stactus_open_incident The details of open (not resolved) incidents.
 # TYPE stactus_open_incident gauge
+stactus_open_incident{id="ir1",impact="major",status_page="test-SP"} 1
 stactus_open_incident{id="test1",impact="critical",status_page="test-SP"} 1
 stactus_open_incident{id="test2",impact="minor",status_page="test-SP"} 1
 stactus_open_incident{id="test3",impact="none",status_page="test-SP"} 1
+# HELP stactus_open_incident_duration_seconds The duration of the open (not resolved) incidents at generation time.
+# TYPE stactus_open_incident_duration_seconds gauge
+stactus_open_incident_duration_seconds{id="ir1",impact="major",status_page="test-SP"} 7200
+stactus_open_incident_duration_seconds{id="test1",impact="critical",status_page="test-SP"} 5400
+stactus_open_incident_duration_seconds{id="test2",impact="minor",status_page="test-SP"} 600
+stactus_open_incident_duration_seconds{id="test3",impact="none",status_page="test-SP"} 60
+# HELP stactus_open_incident_last_update_age_seconds The time since the last update of the open (not resolved) incidents at generation time.
+# TYPE stactus_open_incident_last_update_age_seconds gauge
+stactus_open_incident_last_update_age_seconds{id="test1",impact="critical",status_page="test-SP"} 1800
+stactus_open_incident_last_update_age_seconds{id="test2",impact="minor",status_page="test-SP"} 600
+# HELP stactus_system_incidents The number of incidents per system.
+# TYPE stactus_system_incidents gauge
+stactus_system_incidents{id="s1",impact="major",status_page="test-SP"} 1
+stactus_system_incidents{id="s1",impact="minor",status_page="test-SP"} 1
+stactus_system_incidents{id="s2",impact="critical",status_page="test-SP"} 1
+stactus_system_incidents{id="s2",impact="major",status_page="test-SP"} 1
+stactus_system_incidents{id="s3",impact="minor",status_page="test-SP"} 1
+stactus_system_incidents{id="s4",impact="minor",status_page="test-SP"} 1
+# HELP stactus_system_last_incident_end_timestamp_seconds The end timestamp of the latest incident of the system, missing if the incident is not resolved.
+# TYPE stactus_system_last_incident_end_timestamp_seconds gauge
+stactus_system_last_incident_end_timestamp_seconds{id="s4",status_page="test-SP"} 1.7276076e+09
+# HELP stactus_system_last_incident_start_timestamp_seconds The start timestamp of the latest incident of the system.
+# TYPE stactus_system_last_incident_start_timestamp_seconds gauge
+stactus_system_last_incident_start_timestamp_seconds{id="s1",status_page="test-SP"} 1.7277696e+09
+stactus_system_last_incident_start_timestamp_seconds{id="s4",status_page="test-SP"} 1.727604e+09
 # HELP stactus_system_status Tells Systems are operational or not.
 # TYPE stactus_system_status gauge
 stactus_system_status{id="s1",impact="major",name="System 1",status_ok="false",status_page="test-SP"} 1
@@ -81,6 +140,58 @@ stactus_system_status{id="s3",impact="minor",name="System 3",status_ok="false",s
 stactus_system_status{id="s4",impact="none",name="System 4",status_ok="true",status_page="test-SP"} 1
 			`,
 		},
+
+		"Customizing the metrics format, namespace and labels should render the metrics with them.": {
+			ui: func() model.UI {
+				return model.UI{
+					SystemDetails: []model.SystemDetails{
+						{
+							System: model.System{ID: "s1", Name: "System 1"},
+							Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
+						},
+					},
+					Settings: model.StatusPageSettings{
+						Name: "test-SP",
+						Metrics: model.MetricsSettings{
+							Format:    model.MetricsFormatOpenMetrics,
+							Namespace: "statuspage",
+							Labels:    map[string]string{"environment": "prod", "region": "eu-west-1"},
+						},
+					},
+					Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
+				}
+			},
+			expMetrics: `
+# HELP statuspage_all_systems_status Tells if all systems are operational or not.
+# TYPE statuspage_all_systems_status gauge
+statuspage_all_systems_status{environment="prod",region="eu-west-1",status_ok="true",status_page="test-SP"} 1.0
+# HELP statuspage_build_info The information of the stactus build that generated the status page.
+# TYPE statuspage_build_info gauge
+statuspage_build_info{environment="prod",region="eu-west-1",status_page="test-SP",version="v1.2.3"} 1.0
+# HELP statuspage_generated_timestamp_seconds The timestamp when the status page was generated.
+# TYPE statuspage_generated_timestamp_seconds gauge
+statuspage_generated_timestamp_seconds{environment="prod",region="eu-west-1",status_page="test-SP"} 1.7277768e+09
+# HELP statuspage_incident_mttr_seconds The MTTR based on all the incident history.
+# TYPE statuspage_incident_mttr_seconds gauge
+statuspage_incident_mttr_seconds{environment="prod",region="eu-west-1",status_page="test-SP"} 0.0
+# HELP statuspage_system_status Tells Systems are operational or not.
+# TYPE statuspage_system_status gauge
+statuspage_system_status{environment="prod",id="s1",impact="none",name="System 1",region="eu-west-1",status_ok="true",status_page="test-SP"} 1.0
+# EOF			`,
+		},
+
+		"Extra labels colliding with the metrics series labels should fail.": {
+			ui: func() model.UI {
+				return model.UI{
+					Settings: model.StatusPageSettings{
+						Name:    "test-SP",
+						Metrics: model.MetricsSettings{Labels: map[string]string{"id": "prod"}},
+					},
+					Status: model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
+				}
+			},
+			expErr: true,
+		},
 	}
 
 	for name, test := range tests {
@@ -92,6 +203,8 @@ stactus_system_status{id="s4",impact="none",name="System 4",status_ok="true",sta
 			repo, err := prometheus.NewFSRepository(prometheus.RepositoryConfig{
 				FileManager:     fsm,
 				MetricsFilePath: "test/metrics",
+				Version:         "v1.2.3",
+				TimeNow:         func() time.Time { return t0 },
 			})
 			require.NoError(err)
 
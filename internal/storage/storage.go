@@ -24,6 +24,12 @@ type IncidentReportGetter interface {
 
 //go:generate mockery --case underscore --output storagemock --outpkg storagemock --name IncidentReportGetter
 
+type PageGetter interface {
+	ListAllPages(ctx context.Context) ([]model.Page, error)
+}
+
+//go:generate mockery --case underscore --output storagemock --outpkg storagemock --name PageGetter
+
 type UICreator interface {
 	CreateUI(ctx context.Context, ui model.UI) error
 }
@@ -41,3 +47,27 @@ type FeedCreator interface {
 }
 
 //go:generate mockery --case underscore --output storagemock --outpkg storagemock --name FeedCreator
+
+type BadgeCreator interface {
+	CreateBadges(ctx context.Context, ui model.UI) error
+}
+
+//go:generate mockery --case underscore --output storagemock --outpkg storagemock --name BadgeCreator
+
+type WidgetCreator interface {
+	CreateWidget(ctx context.Context, ui model.UI) error
+}
+
+//go:generate mockery --case underscore --output storagemock --outpkg storagemock --name WidgetCreator
+
+type DataCreator interface {
+	CreateData(ctx context.Context, ui model.UI) error
+}
+
+//go:generate mockery --case underscore --output storagemock --outpkg storagemock --name DataCreator
+
+type OGImageCreator interface {
+	CreateOGImages(ctx context.Context, ui model.UI) error
+}
+
+//go:generate mockery --case underscore --output storagemock --outpkg storagemock --name OGImageCreator
@@ -0,0 +1,114 @@
+// Stactus status widget.
+//
+// Usage: <script src="{STATUS_PAGE_URL}/widget.js" async></script>
+//
+// Optional attributes on the script tag:
+// - data-target: CSS selector of the element where the banner will be placed (by default fixed on the top of the page).
+//
+// The banner can be customized with these CSS variables:
+// --stactus-widget-font-family, --stactus-widget-font-size, --stactus-widget-color, --stactus-widget-link-color,
+// --stactus-widget-bg, --stactus-widget-minor-bg, --stactus-widget-major-bg, --stactus-widget-critical-bg,
+// --stactus-widget-padding, --stactus-widget-z-index.
+(function () {
+  "use strict";
+
+  var statusURL = {{ .StatusURL }};
+  var dismissKey = "stactus-widget-dismissed:" + statusURL;
+  var script = document.currentScript;
+  var target = script ? script.getAttribute("data-target") : null;
+
+  var css = "" +
+    ".stactus-widget{font-family:var(--stactus-widget-font-family,system-ui,sans-serif);font-size:var(--stactus-widget-font-size,14px);color:var(--stactus-widget-color,#fff);background:var(--stactus-widget-bg,#555);padding:var(--stactus-widget-padding,8px 40px 8px 16px);position:relative;box-sizing:border-box;width:100%;}" +
+    ".stactus-widget-fixed{position:fixed;top:0;left:0;right:0;z-index:var(--stactus-widget-z-index,9999);}" +
+    ".stactus-widget-minor,.stactus-widget-none{background:var(--stactus-widget-minor-bg,#d29d00);}" +
+    ".stactus-widget-major{background:var(--stactus-widget-major-bg,#e8590c);}" +
+    ".stactus-widget-critical{background:var(--stactus-widget-critical-bg,#c92a2a);}" +
+    ".stactus-widget a{color:var(--stactus-widget-link-color,inherit);text-decoration:underline;}" +
+    ".stactus-widget-item{margin:2px 0;}" +
+    ".stactus-widget-close{position:absolute;top:4px;right:8px;background:none;border:none;color:inherit;font-size:18px;line-height:1;cursor:pointer;}";
+
+  function signature(incidents) {
+    return incidents.map(function (i) {
+      return i.id + "@" + (i.latestUpdate ? i.latestUpdate.ts : "");
+    }).join(",");
+  }
+
+  function isDismissed(sig) {
+    try {
+      return window.localStorage.getItem(dismissKey) === sig;
+    } catch (e) {
+      return false;
+    }
+  }
+
+  function dismiss(sig) {
+    try {
+      window.localStorage.setItem(dismissKey, sig);
+    } catch (e) {}
+  }
+
+  function render(status) {
+    var incidents = status.incidents || [];
+    if (incidents.length === 0) {
+      return;
+    }
+
+    // Don't show again the same incidents (and updates) once dismissed.
+    var sig = signature(incidents);
+    if (isDismissed(sig)) {
+      return;
+    }
+
+    var style = document.createElement("style");
+    style.textContent = css;
+    document.head.appendChild(style);
+
+    var banner = document.createElement("div");
+    banner.className = "stactus-widget stactus-widget-" + (status.status.impact || "none");
+    banner.setAttribute("role", "status");
+
+    incidents.forEach(function (i) {
+      var item = document.createElement("div");
+      item.className = "stactus-widget-item";
+      var link = document.createElement("a");
+      link.href = i.url;
+      link.target = "_blank";
+      link.rel = "noopener";
+      link.textContent = i.name;
+      item.appendChild(document.createTextNode(status.name + ": "));
+      item.appendChild(link);
+      banner.appendChild(item);
+    });
+
+    var close = document.createElement("button");
+    close.className = "stactus-widget-close";
+    close.setAttribute("aria-label", "Dismiss");
+    close.textContent = "×";
+    close.addEventListener("click", function () {
+      dismiss(sig);
+      banner.parentNode.removeChild(banner);
+    });
+    banner.appendChild(close);
+
+    var container = target ? document.querySelector(target) : null;
+    if (container) {
+      container.appendChild(banner);
+    } else {
+      banner.className += " stactus-widget-fixed";
+      document.body.appendChild(banner);
+    }
+  }
+
+  function load() {
+    fetch(statusURL, { cache: "no-store" })
+      .then(function (resp) { return resp.json(); })
+      .then(render)
+      .catch(function () {});
+  }
+
+  if (document.readyState === "loading") {
+    document.addEventListener("DOMContentLoaded", load);
+  } else {
+    load();
+  }
+})();
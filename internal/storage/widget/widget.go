@@ -0,0 +1,158 @@
+package widget
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"text/template"
+	"time"
+
+	"github.com/slok/stactus/internal/conventions"
+	"github.com/slok/stactus/internal/model"
+	utilfs "github.com/slok/stactus/internal/util/fs"
+)
+
+var (
+	//go:embed templates/widget.js
+	widgetJSTplData string
+
+	widgetJSTpl = template.Must(template.New("widget.js").Parse(widgetJSTplData))
+)
+
+type RepositoryConfig struct {
+	FileManager utilfs.FileManager
+	OutPath     string
+	TimeNow     func() time.Time
+}
+
+func (c *RepositoryConfig) defaults() error {
+	if c.FileManager == nil {
+		c.FileManager = utilfs.StdFileManager
+	}
+
+	if c.OutPath == "" {
+		return fmt.Errorf("out path is required")
+	}
+	c.OutPath = filepath.Clean(c.OutPath)
+
+	if c.TimeNow == nil {
+		c.TimeNow = func() time.Time { return time.Now().UTC() }
+	}
+
+	return nil
+}
+
+// Repository knows how to create an embeddable status widget, this is a script
+// that shows a banner on the embedding page when there are ongoing incidents,
+// based on a compact JSON status.
+type Repository struct {
+	fileManager utilfs.FileManager
+	outPath     string
+	timeNow     func() time.Time
+}
+
+func NewFSRepository(config RepositoryConfig) (*Repository, error) {
+	err := config.defaults()
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return &Repository{
+		fileManager: config.FileManager,
+		outPath:     config.OutPath,
+		timeNow:     config.TimeNow,
+	}, nil
+}
+
+func (r Repository) CreateWidget(ctx context.Context, ui model.UI) error {
+	// Status.
+	status := statusJSON{
+		Name: ui.Settings.Name,
+		URL:  ui.Settings.URL,
+		Status: statusJSONStatus{
+			Operational: ui.Status.Operational,
+			Impact:      string(ui.Status.Impact),
+		},
+		Incidents:   []statusJSONIncident{},
+		GeneratedAt: r.timeNow().UTC(),
+	}
+
+	for _, ir := range ui.OpenedIRs {
+		inc := statusJSONIncident{
+			ID:     ir.ID,
+			Name:   ir.Name,
+			Impact: string(ir.Impact),
+			URL:    conventions.IRDetailURL(ui.Settings.URL, ir.ID),
+		}
+		if len(ir.Timeline) > 0 {
+			latest := ir.Timeline[0]
+			inc.LatestUpdate = &statusJSONUpdate{
+				Kind: string(latest.Kind),
+				TS:   latest.TS.UTC(),
+			}
+		}
+		status.Incidents = append(status.Incidents, inc)
+	}
+
+	statusData, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("could not render widget status: %w", err)
+	}
+
+	err = r.fileManager.WriteFile(ctx, filepath.Join(r.outPath, conventions.WidgetStatusPathName), statusData)
+	if err != nil {
+		return fmt.Errorf("could not write widget status: %w", err)
+	}
+
+	// Widget script.
+	statusURL, err := json.Marshal(conventions.WidgetStatusURL(ui.Settings.URL))
+	if err != nil {
+		return fmt.Errorf("could not render widget status URL: %w", err)
+	}
+
+	var b bytes.Buffer
+	err = widgetJSTpl.Execute(&b, widgetJSTplParams{StatusURL: string(statusURL)})
+	if err != nil {
+		return fmt.Errorf("could not render widget script: %w", err)
+	}
+
+	err = r.fileManager.WriteFile(ctx, filepath.Join(r.outPath, conventions.WidgetJSPathName), b.Bytes())
+	if err != nil {
+		return fmt.Errorf("could not write widget script: %w", err)
+	}
+
+	return nil
+}
+
+type statusJSON struct {
+	Name        string               `json:"name"`
+	URL         string               `json:"url"`
+	Status      statusJSONStatus     `json:"status"`
+	Incidents   []statusJSONIncident `json:"incidents"`
+	GeneratedAt time.Time            `json:"generatedAt"`
+}
+
+type statusJSONStatus struct {
+	Operational bool   `json:"operational"`
+	Impact      string `json:"impact"`
+}
+
+type statusJSONIncident struct {
+	ID           string            `json:"id"`
+	Name         string            `json:"name"`
+	Impact       string            `json:"impact"`
+	URL          string            `json:"url"`
+	LatestUpdate *statusJSONUpdate `json:"latestUpdate,omitempty"`
+}
+
+type statusJSONUpdate struct {
+	Kind string    `json:"kind"`
+	TS   time.Time `json:"ts"`
+}
+
+type widgetJSTplParams struct {
+	StatusURL string // Already JSON (JS) encoded.
+}
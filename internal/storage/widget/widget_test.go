@@ -0,0 +1,94 @@
+package widget_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/slok/stactus/internal/model"
+	"github.com/slok/stactus/internal/storage/widget"
+	utilfs "github.com/slok/stactus/internal/util/fs"
+)
+
+func TestRepositoryCreateWidget(t *testing.T) {
+	t0, _ := time.Parse(time.RFC3339, "2024-10-01T10:00:00Z")
+
+	tests := map[string]struct {
+		ui          func() model.UI
+		expStatus   string
+		expWidgetJS []string
+		expErr      bool
+	}{
+		"Without open incidents the status should be operational.": {
+			ui: func() model.UI {
+				return model.UI{
+					Settings: model.StatusPageSettings{Name: "Test", URL: "https://status.slok.dev"},
+					Status:   model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
+				}
+			},
+			expStatus: `{"name":"Test","url":"https://status.slok.dev","status":{"operational":true,"impact":"none"},"incidents":[],"generatedAt":"2024-10-01T10:00:00Z"}`,
+			expWidgetJS: []string{
+				`var statusURL = "https://status.slok.dev/status.json";`,
+			},
+		},
+
+		"The site URL trailing slash should not be repeated on the status URL.": {
+			ui: func() model.UI {
+				return model.UI{
+					Settings: model.StatusPageSettings{Name: "Test", URL: "https://status.slok.dev/"},
+					Status:   model.SystemStatus{Operational: true, Impact: model.IncidentImpactNone},
+				}
+			},
+			expStatus: `{"name":"Test","url":"https://status.slok.dev/","status":{"operational":true,"impact":"none"},"incidents":[],"generatedAt":"2024-10-01T10:00:00Z"}`,
+			expWidgetJS: []string{
+				`var statusURL = "https://status.slok.dev/status.json";`,
+			},
+		},
+
+		"Open incidents should be on the status.": {
+			ui: func() model.UI {
+				return model.UI{
+					Settings: model.StatusPageSettings{Name: "Test", URL: "https://status.slok.dev"},
+					Status:   model.SystemStatus{Impact: model.IncidentImpactMajor, OpenIRIDs: []string{"ir1", "ir2"}},
+					OpenedIRs: []*model.IncidentReport{
+						{ID: "ir1", Name: "IR 1", Impact: model.IncidentImpactMajor, Timeline: []model.IncidentReportEvent{
+							{TS: t0.Add(-10 * time.Minute), Kind: model.IncidentUpdateKindUpdate},
+							{TS: t0.Add(-30 * time.Minute), Kind: model.IncidentUpdateKindInvestigating},
+						}},
+						{ID: "ir2", Name: "IR 2", Impact: model.IncidentImpactMinor},
+					},
+				}
+			},
+			expStatus: `{"name":"Test","url":"https://status.slok.dev","status":{"operational":false,"impact":"major"},"incidents":[{"id":"ir1","name":"IR 1","impact":"major","url":"https://status.slok.dev/ir/ir1","latestUpdate":{"kind":"update","ts":"2024-10-01T09:50:00Z"}},{"id":"ir2","name":"IR 2","impact":"minor","url":"https://status.slok.dev/ir/ir2"}],"generatedAt":"2024-10-01T10:00:00Z"}`,
+			expWidgetJS: []string{
+				`var statusURL = "https://status.slok.dev/status.json";`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			fsm := utilfs.NewTestFileManager()
+			repo, err := widget.NewFSRepository(widget.RepositoryConfig{
+				FileManager: fsm,
+				OutPath:     "test",
+				TimeNow:     func() time.Time { return t0 },
+			})
+			require.NoError(err)
+
+			err = repo.CreateWidget(context.TODO(), test.ui())
+			if test.expErr {
+				assert.Error(err)
+			} else if assert.NoError(err) {
+				fsm.AssertEqual(t, "test/status.json", test.expStatus)
+				fsm.AssertContains(t, "test/widget.js", test.expWidgetJS)
+			}
+		})
+	}
+}
@@ -81,6 +81,18 @@ func (f TestFileManager) AssertContains(t *testing.T, path string, exp []string)
 	}
 }
 
+func (f TestFileManager) AssertNotContains(t *testing.T, path string, notExp []string) {
+	got, ok := f.files[path]
+	if !ok {
+		assert.Fail(t, "path missing", path)
+		return
+	}
+
+	for _, e := range notExp {
+		assert.NotContains(t, got, e)
+	}
+}
+
 func (f TestFileManager) AssertEqual(t *testing.T, path string, exp string) {
 	got, ok := f.files[path]
 	if !ok {
@@ -92,3 +104,8 @@ func (f TestFileManager) AssertEqual(t *testing.T, path string, exp string) {
 
 	assert.Equal(t, exp, got)
 }
+
+func (f TestFileManager) AssertNotExists(t *testing.T, path string) {
+	_, ok := f.files[path]
+	assert.False(t, ok, "path %q should not exist", path)
+}
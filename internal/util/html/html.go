@@ -1,20 +1,607 @@
 package html
 
 import (
+	"bufio"
 	"bytes"
 	"fmt"
+	stdhtml "html"
 	"html/template"
+	"io"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
 
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/microcosm-cc/bluemonday"
 	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	gmhtml "github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"golang.org/x/net/html"
+
+	"github.com/slok/stactus/internal/conventions"
 )
 
-// RenderMarkdownToHTML will get a markdown string and render to HTML.
-func RenderMarkdownToHTML(mdText string) (template.HTML, error) {
+// URLResolver returns the URL of a relative reference of a Markdown content (e.g: `./img/graph.png`).
+type URLResolver func(ref string) string
+
+// HashedAsset is a file referenced by a Markdown content that is served by its content hashed path.
+type HashedAsset interface {
+	HashedPath() string
+}
+
+// AssetURLResolver returns the resolver of the Markdown relative references to their asset URLs
+// on the site, the references without asset are kept as they are.
+func AssetURLResolver[A HashedAsset](siteURL string, assets map[string]A) URLResolver {
+	return func(ref string) string {
+		a, ok := assets[ref]
+		if !ok {
+			return ref
+		}
+
+		url := conventions.AssetURL(siteURL, a.HashedPath())
+		if _, fragment, ok := strings.Cut(ref, "#"); ok {
+			url += "#" + fragment
+		}
+		return url
+	}
+}
+
+// MarkdownRendererConfig is the configuration of the Markdown renderer.
+type MarkdownRendererConfig struct {
+	// GFM extensions, all of them are enabled by default.
+	DisableTables        bool
+	DisableStrikethrough bool
+	DisableAutolinks     bool
+	DisableTaskLists     bool
+	DisableFootnotes     bool
+	// RawHTML renders the raw HTML of the Markdown (sanitized), by default it's omitted.
+	RawHTML bool
+	// HeadingAnchors adds a link to each heading so it can be shared.
+	HeadingAnchors bool
+	// CodeHighlightStyle is the Chroma style used to highlight the code blocks (e.g: `github`),
+	// if empty the code blocks will not be highlighted.
+	CodeHighlightStyle string
+	// SiteURL is used to know what links are external, if empty all the absolute links are external.
+	SiteURL string
+	// ExternalLinkRel is the `rel` of the external links, if empty `noopener noreferrer` will be used.
+	ExternalLinkRel string
+	// ExternalLinkTarget is the `target` of the external links, if empty it will not be set. The
+	// `_blank` target will always have the `noopener` rel.
+	ExternalLinkTarget string
+}
+
+var (
+	linkRelRegexp    = regexp.MustCompile(`^[a-z]+( [a-z]+)*$`)
+	linkTargetRegexp = regexp.MustCompile(`^_(blank|self|parent|top)$`)
+)
+
+// ValidateCodeHighlightStyle returns an error if the code highlight style is not a known Chroma
+// style, empty is valid (no highlighting).
+func ValidateCodeHighlightStyle(style string) error {
+	if style == "" {
+		return nil
+	}
+
+	if _, ok := styles.Registry[style]; !ok {
+		return fmt.Errorf("unknown code highlight style %q", style)
+	}
+
+	return nil
+}
+
+// ValidateExternalLinkRel returns an error if the external link `rel` is not a space separated
+// list of link types (e.g: `noopener nofollow`), empty is valid (default).
+func ValidateExternalLinkRel(rel string) error {
+	if rel != "" && !linkRelRegexp.MatchString(rel) {
+		return fmt.Errorf("invalid external link rel %q", rel)
+	}
+
+	return nil
+}
+
+// ValidateExternalLinkTarget returns an error if the external link `target` is not a browsing
+// context keyword (e.g: `_blank`), empty is valid (not set).
+func ValidateExternalLinkTarget(target string) error {
+	if target != "" && !linkTargetRegexp.MatchString(target) {
+		return fmt.Errorf("unknown external link target %q", target)
+	}
+
+	return nil
+}
+
+func (c *MarkdownRendererConfig) defaults() error {
+	err := ValidateCodeHighlightStyle(c.CodeHighlightStyle)
+	if err != nil {
+		return err
+	}
+
+	c.ExternalLinkRel = strings.Join(strings.Fields(c.ExternalLinkRel), " ")
+	if c.ExternalLinkRel == "" {
+		c.ExternalLinkRel = "noopener noreferrer"
+	}
+	err = ValidateExternalLinkRel(c.ExternalLinkRel)
+	if err != nil {
+		return err
+	}
+
+	err = ValidateExternalLinkTarget(c.ExternalLinkTarget)
+	if err != nil {
+		return err
+	}
+
+	// The opened pages should not have access to the status page (reverse tabnabbing).
+	if c.ExternalLinkTarget == "_blank" && !slices.Contains(strings.Fields(c.ExternalLinkRel), "noopener") {
+		c.ExternalLinkRel += " noopener"
+	}
+
+	return nil
+}
+
+// MarkdownRenderer renders Markdown contents to safe HTML, the resulting HTML is always
+// sanitized with an allowlist, as the contents can come from untrusted sources (e.g: pasted tickets).
+type MarkdownRenderer struct {
+	md                 goldmark.Markdown
+	documentMD         goldmark.Markdown
+	sanitizer          *bluemonday.Policy
+	rawHTML            bool
+	headingAnchors     bool
+	siteHost           string
+	externalLinkRel    string
+	externalLinkTarget string
+}
+
+// NewMarkdownRenderer returns a new Markdown renderer.
+func NewMarkdownRenderer(config MarkdownRendererConfig) (*MarkdownRenderer, error) {
+	err := config.defaults()
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	exts := []goldmark.Extender{}
+	if !config.DisableTables {
+		exts = append(exts, extension.Table)
+	}
+	if !config.DisableStrikethrough {
+		exts = append(exts, extension.Strikethrough)
+	}
+	if !config.DisableAutolinks {
+		exts = append(exts, extension.Linkify)
+	}
+	if !config.DisableTaskLists {
+		exts = append(exts, extension.TaskList)
+	}
+	if !config.DisableFootnotes {
+		exts = append(exts, extension.Footnote)
+	}
+	if config.CodeHighlightStyle != "" {
+		exts = append(exts, highlighting.NewHighlighting(highlighting.WithStyle(config.CodeHighlightStyle)))
+	}
+
+	rendererOpts := []renderer.Option{}
+	if config.RawHTML {
+		// Safe because the result is always sanitized.
+		rendererOpts = append(rendererOpts, gmhtml.WithUnsafe())
+	}
+
+	// Only the documents require heading IDs by default (e.g: table of contents).
+	parserOpts := []parser.Option{}
+	if config.HeadingAnchors {
+		parserOpts = append(parserOpts, parser.WithAutoHeadingID())
+	}
+
+	var siteHost string
+	if u, err := url.Parse(config.SiteURL); err == nil {
+		siteHost = u.Host
+	}
+
+	return &MarkdownRenderer{
+		md: goldmark.New(
+			goldmark.WithExtensions(exts...),
+			goldmark.WithParserOptions(parserOpts...),
+			goldmark.WithRendererOptions(rendererOpts...),
+		),
+		documentMD: goldmark.New(
+			goldmark.WithExtensions(exts...),
+			goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+			goldmark.WithRendererOptions(rendererOpts...),
+		),
+		sanitizer:          newMarkdownSanitizer(),
+		rawHTML:            config.RawHTML,
+		headingAnchors:     config.HeadingAnchors,
+		siteHost:           siteHost,
+		externalLinkRel:    config.ExternalLinkRel,
+		externalLinkTarget: config.ExternalLinkTarget,
+	}, nil
+}
+
+// newMarkdownSanitizer returns the allowlist of the HTML that the rendered Markdown can have.
+func newMarkdownSanitizer() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	// The external links `rel` is set by the renderer settings.
+	p.RequireNoFollowOnLinks(false)
+
+	// Links.
+	p.AllowAttrs("rel").Matching(linkRelRegexp).OnElements("a")
+	p.AllowAttrs("target").Matching(linkTargetRegexp).OnElements("a")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^(footnote-ref|footnote-backref|heading-anchor)$`)).OnElements("a")
+
+	// Code blocks.
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#.-]+$`)).OnElements("code")
+	p.AllowAttrs("tabindex").Matching(regexp.MustCompile(`^0$`)).OnElements("pre")
+	p.AllowStyles("color", "background-color", "font-weight", "font-style", "text-decoration").OnElements("pre", "span")
+	p.AllowStyles("display").MatchingEnum("flex").OnElements("span")
+
+	// Footnotes.
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^footnotes$`)).OnElements("div")
+	p.AllowAttrs("role").Matching(regexp.MustCompile(`^doc-(noteref|backlink|endnotes)$`)).OnElements("a", "div")
+
+	// Task lists.
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").Matching(regexp.MustCompile(`^$`)).OnElements("input")
+
+	// Tables.
+	p.AllowStyles("text-align").MatchingEnum("left", "center", "right").OnElements("th", "td")
+
+	return p
+}
+
+// RenderToHTML will get a markdown string and render to HTML. If set, resolveURL
+// will be used to rewrite the relative image and link URLs.
+func (r MarkdownRenderer) RenderToHTML(mdText string, resolveURL URLResolver) (template.HTML, error) {
+	source := []byte(mdText)
+	doc := r.md.Parser().Parse(text.NewReader(source))
+
+	err := ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if entering {
+			r.processNode(n, source, resolveURL)
+		}
+		return ast.WalkContinue, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not process markdown: %w", err)
+	}
+
 	var b bytes.Buffer
-	err := goldmark.Convert([]byte(mdText), &b)
+	err = r.md.Renderer().Render(&b, source, doc)
 	if err != nil {
 		return "", fmt.Errorf("could not convert markdown to HTML: %w", err)
 	}
 
-	return template.HTML(b.Bytes()), nil
+	out, err := r.rewriteRawHTMLURLs(b.Bytes(), resolveURL)
+	if err != nil {
+		return "", fmt.Errorf("could not process raw HTML: %w", err)
+	}
+
+	return template.HTML(r.sanitizer.SanitizeBytes(out)), nil
+}
+
+// MarkdownHeading is a heading of a Markdown document.
+type MarkdownHeading struct {
+	Level int
+	ID    string
+	Text  string
+}
+
+// RenderDocumentToHTML will render a whole markdown document (e.g: a postmortem) to HTML. The
+// headings will have an ID so they can be linked and are returned (e.g: for a table of contents).
+// If set, resolveURL will be used to rewrite the relative image and link URLs.
+func (r MarkdownRenderer) RenderDocumentToHTML(mdText string, resolveURL URLResolver) (template.HTML, []MarkdownHeading, error) {
+	source := []byte(mdText)
+	doc := r.documentMD.Parser().Parse(text.NewReader(source))
+
+	headings := []MarkdownHeading{}
+	err := ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		if n, ok := n.(*ast.Heading); ok {
+			headings = append(headings, MarkdownHeading{
+				Level: n.Level,
+				ID:    headingID(n),
+				Text:  inlineText(n, source),
+			})
+		}
+		r.processNode(n, source, resolveURL)
+
+		return ast.WalkContinue, nil
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("could not process markdown: %w", err)
+	}
+
+	var b bytes.Buffer
+	err = r.documentMD.Renderer().Render(&b, source, doc)
+	if err != nil {
+		return "", nil, fmt.Errorf("could not convert markdown to HTML: %w", err)
+	}
+
+	out, err := r.rewriteRawHTMLURLs(b.Bytes(), resolveURL)
+	if err != nil {
+		return "", nil, fmt.Errorf("could not process raw HTML: %w", err)
+	}
+
+	return template.HTML(r.sanitizer.SanitizeBytes(out)), headings, nil
+}
+
+// processNode applies the renderer settings to a Markdown node before being rendered.
+func (r MarkdownRenderer) processNode(n ast.Node, source []byte, resolveURL URLResolver) {
+	rewriteRelativeURL(n, resolveURL)
+
+	switch n := n.(type) {
+	case *ast.Heading:
+		id := headingID(n)
+		if r.headingAnchors && id != "" {
+			anchor := ast.NewLink()
+			anchor.Destination = []byte("#" + id)
+			anchor.SetAttributeString("class", []byte("heading-anchor"))
+			anchor.AppendChild(anchor, ast.NewString([]byte("#")))
+			n.AppendChild(n, anchor)
+		}
+	case *ast.Link:
+		r.setExternalLinkAttrs(n, string(n.Destination))
+	case *ast.AutoLink:
+		r.setExternalLinkAttrs(n, string(n.URL(source)))
+	}
+}
+
+// setExternalLinkAttrs sets the `rel` and `target` of the link if it goes to another site.
+func (r MarkdownRenderer) setExternalLinkAttrs(n ast.Node, dest string) {
+	u, err := url.Parse(dest)
+	if err != nil || u.Host == "" || u.Host == r.siteHost {
+		return
+	}
+
+	n.SetAttributeString("rel", []byte(r.externalLinkRel))
+	if r.externalLinkTarget != "" {
+		n.SetAttributeString("target", []byte(r.externalLinkTarget))
+	}
+}
+
+// headingID returns the ID of a heading, empty if it doesn't have one.
+func headingID(n *ast.Heading) string {
+	id, _ := n.AttributeString("id")
+	idB, _ := id.([]byte)
+	return string(idB)
+}
+
+// rewriteRelativeURL rewrites the URL of the image and link nodes if they are relative.
+func rewriteRelativeURL(n ast.Node, resolveURL URLResolver) {
+	if resolveURL == nil {
+		return
+	}
+
+	switch n := n.(type) {
+	case *ast.Image:
+		if IsRelativeURL(string(n.Destination)) {
+			n.Destination = []byte(resolveURL(string(n.Destination)))
+		}
+	case *ast.Link:
+		if IsRelativeURL(string(n.Destination)) {
+			n.Destination = []byte(resolveURL(string(n.Destination)))
+		}
+	}
+}
+
+// rewriteRawHTMLURLs rewrites the relative image and link URLs of the raw HTML (if enabled) of the
+// rendered HTML, the Markdown ones have already been rewritten by the time the HTML is rendered.
+func (r MarkdownRenderer) rewriteRawHTMLURLs(b []byte, resolveURL URLResolver) ([]byte, error) {
+	if !r.rawHTML || resolveURL == nil {
+		return b, nil
+	}
+
+	var out bytes.Buffer
+	z := html.NewTokenizer(bytes.NewReader(b))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if z.Err() == io.EOF {
+				return out.Bytes(), nil
+			}
+			return nil, z.Err()
+		}
+
+		// Only the tags with relative URLs are rewritten, the rest is kept untouched.
+		raw := slices.Clone(z.Raw())
+		if tt == html.StartTagToken || tt == html.SelfClosingTagToken {
+			t := z.Token()
+			if rewriteRawHTMLTagURL(&t, resolveURL) {
+				out.WriteString(t.String())
+				continue
+			}
+		}
+		out.Write(raw)
+	}
+}
+
+// rawHTMLURLAttrs are the attributes with the URLs of the raw HTML images and links.
+var rawHTMLURLAttrs = map[string]string{
+	"img": "src",
+	"a":   "href",
+}
+
+// rewriteRawHTMLTagURL rewrites the URL of the raw HTML image and link tags if they are
+// relative, returns true if the tag has been modified.
+func rewriteRawHTMLTagURL(t *html.Token, resolveURL URLResolver) bool {
+	attr, ok := rawHTMLURLAttrs[t.Data]
+	if !ok {
+		return false
+	}
+
+	rewritten := false
+	for i, a := range t.Attr {
+		if a.Key == attr && IsRelativeURL(a.Val) {
+			t.Attr[i].Val = resolveURL(a.Val)
+			rewritten = true
+		}
+	}
+
+	return rewritten
+}
+
+// rawHTMLRelativeReferences returns the relative URLs of the images and links of a raw HTML.
+func rawHTMLRelativeReferences(raw []byte) []string {
+	refs := []string{}
+	z := html.NewTokenizer(bytes.NewReader(raw))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			return refs
+		}
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			continue
+		}
+
+		t := z.Token()
+		attr, ok := rawHTMLURLAttrs[t.Data]
+		if !ok {
+			continue
+		}
+		for _, a := range t.Attr {
+			if a.Key == attr && IsRelativeURL(a.Val) {
+				refs = append(refs, a.Val)
+			}
+		}
+	}
+}
+
+// inlineText returns the plain text of the node inline children (e.g: heading text), as it's
+// rendered (e.g: `\<` and `&lt;` are `<`).
+func inlineText(n ast.Node, source []byte) string {
+	var b bytes.Buffer
+	w := bufio.NewWriter(&b)
+	_ = ast.Walk(n, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := n.(type) {
+		case *ast.CodeSpan:
+			// Code is rendered as it is, without escapes nor references.
+			for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+				if t, ok := c.(*ast.Text); ok {
+					_, _ = w.WriteString(template.HTMLEscapeString(string(t.Segment.Value(source))))
+				}
+			}
+			return ast.WalkSkipChildren, nil
+		case *ast.Text:
+			gmhtml.DefaultWriter.Write(w, n.Segment.Value(source))
+		case *ast.String:
+			_, _ = w.WriteString(template.HTMLEscapeString(string(n.Value)))
+		}
+		return ast.WalkContinue, nil
+	})
+	_ = w.Flush()
+
+	return stdhtml.UnescapeString(b.String())
+}
+
+// MarkdownRelativeReferences returns the relative URLs of the images and links of a markdown
+// content (e.g: `./img/graph.png`), without repetitions. The raw HTML `<img src>` and `<a href>`
+// are included too, in case the raw HTML is enabled.
+func MarkdownRelativeReferences(mdText string) []string {
+	source := []byte(mdText)
+	doc := goldmark.DefaultParser().Parse(text.NewReader(source))
+
+	refs := []string{}
+	seen := map[string]bool{}
+	addRef := func(dest string) {
+		if IsRelativeURL(dest) && !seen[dest] {
+			seen[dest] = true
+			refs = append(refs, dest)
+		}
+	}
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch n := n.(type) {
+		case *ast.Image:
+			addRef(string(n.Destination))
+		case *ast.Link:
+			addRef(string(n.Destination))
+		case *ast.RawHTML:
+			for _, ref := range rawHTMLRelativeReferences(n.Segments.Value(source)) {
+				addRef(ref)
+			}
+		case *ast.HTMLBlock:
+			var raw bytes.Buffer
+			for i := 0; i < n.Lines().Len(); i++ {
+				line := n.Lines().At(i)
+				raw.Write(line.Value(source))
+			}
+			if n.HasClosure() {
+				raw.Write(n.ClosureLine.Value(source))
+			}
+			for _, ref := range rawHTMLRelativeReferences(raw.Bytes()) {
+				addRef(ref)
+			}
+		}
+
+		return ast.WalkContinue, nil
+	})
+
+	return refs
+}
+
+// IsRelativeURL returns true if the URL is relative to the document (not absolute, root relative or an anchor).
+func IsRelativeURL(u string) bool {
+	if u == "" || strings.HasPrefix(u, "/") || strings.HasPrefix(u, "#") {
+		return false
+	}
+
+	pu, err := url.Parse(u)
+	if err != nil {
+		return false
+	}
+
+	return pu.Scheme == "" && pu.Host == ""
+}
+
+// RenderMarkdownToText will get a markdown string and render it to plain text (e.g: for search indexes).
+func RenderMarkdownToText(mdText string) (string, error) {
+	source := []byte(mdText)
+	doc := goldmark.DefaultParser().Parse(text.NewReader(source))
+
+	var b strings.Builder
+	err := ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			// Separate blocks so words don't get merged.
+			if n.Type() == ast.TypeBlock {
+				b.WriteString(" ")
+			}
+			return ast.WalkContinue, nil
+		}
+
+		switch n := n.(type) {
+		case *ast.Text:
+			b.Write(n.Segment.Value(source))
+			if n.SoftLineBreak() || n.HardLineBreak() {
+				b.WriteString(" ")
+			}
+		case *ast.String:
+			b.Write(n.Value)
+		case *ast.AutoLink:
+			b.Write(n.Label(source))
+		case *ast.CodeBlock, *ast.FencedCodeBlock:
+			lines := n.Lines()
+			for i := 0; i < lines.Len(); i++ {
+				line := lines.At(i)
+				b.Write(line.Value(source))
+			}
+		}
+
+		return ast.WalkContinue, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not convert markdown to text: %w", err)
+	}
+
+	return strings.Join(strings.Fields(b.String()), " "), nil
 }
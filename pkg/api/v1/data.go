@@ -0,0 +1,97 @@
+package api
+
+import "time"
+
+const (
+	DataVersionV1 = "data/v1"
+)
+
+// DataV1Index is the entrypoint of the JSON data export, has the status page
+// general information, the systems current status and the stats.
+type DataV1Index struct {
+	Version       string              `json:"version"`
+	Name          string              `json:"name"`
+	URL           string              `json:"url"`
+	GeneratedAt   time.Time           `json:"generatedAt"`
+	Status        DataV1Status        `json:"status"`
+	Stats         DataV1Stats         `json:"stats"`
+	Systems       []DataV1System      `json:"systems"`
+	OpenIncidents []DataV1IncidentRef `json:"openIncidents"`
+	// HistoryURL is the URL of the first history page.
+	HistoryURL string `json:"historyURL"`
+}
+
+type DataV1Status struct {
+	Operational bool   `json:"operational"`
+	Impact      string `json:"impact" jsonschema:"enum=none,enum=minor,enum=major,enum=critical"`
+	// OpenIncidentIDs are the IDs of the ongoing incidents.
+	OpenIncidentIDs []string `json:"openIncidentIDs"`
+}
+
+type DataV1Stats struct {
+	TotalSystems           int     `json:"totalSystems"`
+	TotalIncidents         int     `json:"totalIncidents"`
+	TotalMinorIncidents    int     `json:"totalMinorIncidents"`
+	TotalMajorIncidents    int     `json:"totalMajorIncidents"`
+	TotalCriticalIncidents int     `json:"totalCriticalIncidents"`
+	TotalOpenIncidents     int     `json:"totalOpenIncidents"`
+	MTTRSeconds            float64 `json:"mttrSeconds"`
+}
+
+type DataV1System struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Status      DataV1Status      `json:"status"`
+	Stats       DataV1SystemStats `json:"stats"`
+}
+
+type DataV1SystemStats struct {
+	TotalIncidents int     `json:"totalIncidents"`
+	MTTRSeconds    float64 `json:"mttrSeconds"`
+}
+
+// DataV1IncidentRef is the summary of an incident with the URL to the full incident data.
+type DataV1IncidentRef struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Impact    string     `json:"impact" jsonschema:"enum=none,enum=minor,enum=major,enum=critical"`
+	SystemIDs []string   `json:"systemIDs"`
+	Start     time.Time  `json:"start"`
+	End       *time.Time `json:"end,omitempty"`
+	// URL is the URL of the full incident data.
+	URL string `json:"url"`
+}
+
+// DataV1HistoryPage is a page of the incident history, sorted by the latest first.
+type DataV1HistoryPage struct {
+	Version    string              `json:"version"`
+	Page       int                 `json:"page"`
+	TotalPages int                 `json:"totalPages"`
+	NextURL    string              `json:"nextURL,omitempty"`
+	PrevURL    string              `json:"prevURL,omitempty"`
+	Incidents  []DataV1IncidentRef `json:"incidents"`
+}
+
+// DataV1Incident is the full data of an incident.
+type DataV1Incident struct {
+	Version         string     `json:"version"`
+	ID              string     `json:"id"`
+	Name            string     `json:"name"`
+	Impact          string     `json:"impact" jsonschema:"enum=none,enum=minor,enum=major,enum=critical"`
+	SystemIDs       []string   `json:"systemIDs"`
+	Start           time.Time  `json:"start"`
+	End             *time.Time `json:"end,omitempty"`
+	DurationSeconds float64    `json:"durationSeconds,omitempty"`
+	// HTMLURL is the URL of the incident on the status page.
+	HTMLURL  string                   `json:"htmlURL"`
+	Timeline []DataV1IncidentTimeline `json:"timeline"`
+}
+
+type DataV1IncidentTimeline struct {
+	TS          time.Time `json:"ts"`
+	Kind        string    `json:"kind" jsonschema:"enum=update,enum=investigating,enum=resolved"`
+	Description string    `json:"description"`
+	// PrivateNote is only present on the internal audience data.
+	PrivateNote string `json:"privateNote,omitempty"`
+}
@@ -5,17 +5,54 @@ const (
 )
 
 type IncidentV1 struct {
-	Version  string                    `yaml:"version"`
-	ID       string                    `yaml:"id"`
-	Name     string                    `yaml:"name"`
-	Impact   string                    `yaml:"impact"`
+	Version  string                    `yaml:"version" jsonschema:"required,enum=incident/v1"`
+	ID       string                    `yaml:"id" jsonschema:"required"`
+	Name     string                    `yaml:"name" jsonschema:"required"`
+	Impact   string                    `yaml:"impact" jsonschema:"enum=none,enum=minor,enum=major,enum=critical"`
 	Systems  []string                  `yaml:"systems"`
 	Timeline []IncidentV1TimelineEvent `yaml:"timeline"`
+	// Visibility of the incident (by default `public`):
+	// - `public`: Published on all the status pages.
+	// - `draft`: Never published, only shown by the `serve` cmd with a draft banner (e.g: pending review).
+	// - `internal`: Only published on the internal audience status pages.
+	Visibility string `yaml:"visibility,omitempty" jsonschema:"enum=public,enum=draft,enum=internal"`
+	// Postmortem is the root cause analysis of the incident.
+	Postmortem *IncidentV1Postmortem `yaml:"postmortem,omitempty"`
+	// I18n are the translations of the incident by language (e.g: `es`, `pt-BR`), the missing
+	// ones will fall back to the default language texts.
+	I18n map[string]IncidentV1I18n `yaml:"i18n,omitempty"`
+}
+
+type IncidentV1Postmortem struct {
+	// Content of the postmortem in Markdown, can't be used with `file`.
+	Content string `yaml:"content,omitempty"`
+	// File is the Markdown file of the postmortem relative to the incident file (e.g: `./ir-0001-postmortem.md`),
+	// can't be used with `content`.
+	File string `yaml:"file,omitempty"`
+	// TS is when the postmortem was published (by default when the incident ended), any of the
+	// absolute timeline event formats.
+	TS string `yaml:"ts,omitempty"`
+}
+
+type IncidentV1I18n struct {
+	Name string `yaml:"name,omitempty"`
 }
 
 type IncidentV1TimelineEvent struct {
-	TS            string `yaml:"ts"`
-	Description   string `yaml:"description"`
+	TS            string `yaml:"ts" jsonschema:"required"`
+	Description   string `yaml:"description" jsonschema:"required"`
 	Investigating bool   `yaml:"investigating,omitempty"`
 	Resolved      bool   `yaml:"resolved,omitempty"`
+	// Audience of the update (by default `public`), `internal` updates are only shown on the
+	// internal status page.
+	Audience string `yaml:"audience,omitempty" jsonschema:"enum=public,enum=internal"`
+	// PrivateNote are extra details of the update only shown on the internal status page,
+	// supports Markdown.
+	PrivateNote string `yaml:"privateNote,omitempty"`
+	// I18n are the translations of the event by language (e.g: `es`, `pt-BR`).
+	I18n map[string]IncidentV1TimelineEventI18n `yaml:"i18n,omitempty"`
+}
+
+type IncidentV1TimelineEventI18n struct {
+	Description string `yaml:"description,omitempty"`
 }
@@ -0,0 +1,9 @@
+package api
+
+// PageV1FrontMatter is the YAML front matter of a custom Markdown page, the
+// rest of the file after the front matter will be the Markdown content of the page.
+type PageV1FrontMatter struct {
+	Title    string `yaml:"title"`
+	Slug     string `yaml:"slug,omitempty"`
+	NavOrder int    `yaml:"navOrder,omitempty"`
+}
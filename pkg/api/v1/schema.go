@@ -0,0 +1,70 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/invopop/jsonschema"
+)
+
+//go:generate go run github.com/slok/stactus/cmd/stactus schema stactus --out ./schemas/stactus-v1.json
+//go:generate go run github.com/slok/stactus/cmd/stactus schema incident --out ./schemas/incident-v1.json
+//go:generate go run github.com/slok/stactus/cmd/stactus schema workspace --out ./schemas/workspace-v1.json
+
+// JSON schema URLs of the YAML APIs, these can be used by the editors to validate and
+// autocomplete the files (e.g: `# yaml-language-server: $schema=...`).
+const (
+	StactusV1SchemaURL   = "https://raw.githubusercontent.com/slok/stactus/main/pkg/api/v1/schemas/stactus-v1.json"
+	IncidentV1SchemaURL  = "https://raw.githubusercontent.com/slok/stactus/main/pkg/api/v1/schemas/incident-v1.json"
+	WorkspaceV1SchemaURL = "https://raw.githubusercontent.com/slok/stactus/main/pkg/api/v1/schemas/workspace-v1.json"
+)
+
+// StactusV1JSONSchema returns the JSON schema of the `stactus/v1` YAML API.
+func StactusV1JSONSchema() ([]byte, error) {
+	return yamlJSONSchema(&StactusV1{}, StactusV1SchemaURL)
+}
+
+// IncidentV1JSONSchema returns the JSON schema of the `incident/v1` YAML API.
+func IncidentV1JSONSchema() ([]byte, error) {
+	return yamlJSONSchema(&IncidentV1{}, IncidentV1SchemaURL)
+}
+
+// WorkspaceV1JSONSchema returns the JSON schema of the `workspace/v1` YAML API.
+func WorkspaceV1JSONSchema() ([]byte, error) {
+	return yamlJSONSchema(&WorkspaceV1{}, WorkspaceV1SchemaURL)
+}
+
+func yamlJSONSchema(v any, id string) ([]byte, error) {
+	reflector := jsonschema.Reflector{
+		FieldNameTag: "yaml",
+		// Most of our fields are optional, so only the ones marked explicitly are required.
+		RequiredFromJSONSchemaTags: true,
+	}
+	schema := reflector.Reflect(v)
+	schema.ID = jsonschema.ID(id)
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal JSON schema: %w", err)
+	}
+
+	return append(data, '\n'), nil
+}
+
+// JSONSchemaExtend sets the supported timestamp formats of the timeline events.
+func (IncidentV1TimelineEvent) JSONSchemaExtend(s *jsonschema.Schema) {
+	ts, ok := s.Properties.Get("ts")
+	if !ok {
+		return
+	}
+
+	ts.Description = "Timestamp of the event. Absolute (RFC3339, `2006-01-02 15:04:05`, `2006-01-02 15:04`, `/` and `-` date separators are interchangeable), " +
+		"hour of the previous event day (`15:04`, `15:04:05`, `3:04PM`) or duration added to the previous event (e.g: `+1h30m`)."
+	ts.AnyOf = []*jsonschema.Schema{
+		{Title: "RFC3339", Pattern: `^\d{4}[-/]\d{2}[-/]\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})$`},
+		{Title: "Date time", Pattern: `^\d{4}[-/]\d{2}[-/]\d{2} \d{2}:\d{2}(:\d{2})?$`},
+		{Title: "Hour", Pattern: `^\d{1,2}:\d{2}(:\d{2})?$`},
+		{Title: "Kitchen hour", Pattern: `^\d{1,2}:\d{2}(AM|PM)$`},
+		{Title: "Duration", Pattern: `^\+(\d+(\.\d+)?(ns|us|µs|ms|s|m|h))+$`},
+	}
+}
@@ -0,0 +1,48 @@
+package api_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiv1 "github.com/slok/stactus/pkg/api/v1"
+)
+
+func TestJSONSchemasUpToDate(t *testing.T) {
+	tests := map[string]struct {
+		schema   func() ([]byte, error)
+		filePath string
+	}{
+		"Stactus v1 schema should be up to date.": {
+			schema:   apiv1.StactusV1JSONSchema,
+			filePath: "./schemas/stactus-v1.json",
+		},
+
+		"Incident v1 schema should be up to date.": {
+			schema:   apiv1.IncidentV1JSONSchema,
+			filePath: "./schemas/incident-v1.json",
+		},
+
+		"Workspace v1 schema should be up to date.": {
+			schema:   apiv1.WorkspaceV1JSONSchema,
+			filePath: "./schemas/workspace-v1.json",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			gotSchema, err := test.schema()
+			require.NoError(err)
+
+			expSchema, err := os.ReadFile(test.filePath)
+			require.NoError(err)
+
+			assert.Equal(string(expSchema), string(gotSchema), "Schema is outdated, regenerate it with `go generate`.")
+		})
+	}
+}
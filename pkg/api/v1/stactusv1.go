@@ -5,17 +5,40 @@ const (
 )
 
 type StactusV1 struct {
-	Version string            `yaml:"version"`
-	Name    string            `yaml:"name"`
-	URL     string            `yaml:"url"`
+	Version string            `yaml:"version" jsonschema:"required,enum=stactus/v1"`
+	Name    string            `yaml:"name" jsonschema:"required"`
+	URL     string            `yaml:"url" jsonschema:"required"`
 	Theme   *StactusV1Theme   `yaml:"theme,omitempty"`
-	Systems []StactusV1System `yaml:"systems"`
+	Feed    *StactusV1Feed    `yaml:"feed,omitempty"`
+	Metrics *StactusV1Metrics `yaml:"metrics,omitempty"`
+	SEO     *StactusV1SEO     `yaml:"seo,omitempty"`
+	// Markdown rendering of the incidents and pages contents.
+	Markdown *StactusV1Markdown `yaml:"markdown,omitempty"`
+	// Languages of the status page (e.g: `en`, `es`, `pt-BR`), a site will be generated per
+	// language with a language switcher. The first one is the default language (by default `en`).
+	Languages []string `yaml:"languages,omitempty"`
+	// Audiences that will have their own status page (e.g: a public one for the customers and an
+	// internal one with everything), `generate` will create a status page per audience.
+	Audiences []StactusV1Audience `yaml:"audiences,omitempty"`
+	Systems   []StactusV1System   `yaml:"systems"`
+}
+
+type StactusV1Audience struct {
+	// Name of the audience:
+	// - `public`: Only the public systems, incidents and updates.
+	// - `internal`: Everything, including the internal systems, incidents, updates and private notes.
+	Name string `yaml:"name" jsonschema:"required,enum=public,enum=internal"`
+	// URL of the audience status page (by default the status page `url`).
+	URL string `yaml:"url,omitempty"`
 }
 
 type StactusV1System struct {
-	ID          string `yaml:"id"`
-	Name        string `yaml:"name"`
+	ID          string `yaml:"id" jsonschema:"required"`
+	Name        string `yaml:"name" jsonschema:"required"`
 	Description string `yaml:"description,omitempty"`
+	// Audience of the system (by default `public`), `internal` systems and their incidents are
+	// only shown on the internal status page.
+	Audience string `yaml:"audience,omitempty" jsonschema:"enum=public,enum=internal"`
 }
 
 type StactusV1Theme struct {
@@ -24,4 +47,81 @@ type StactusV1Theme struct {
 
 type StactusV1ThemeSimple struct {
 	ThemePath string `yaml:"themePath,omitempty"`
+	// TranslationsPath is a directory with message catalogs (e.g: `es.yaml`) that override or
+	// add translations to the theme messages.
+	TranslationsPath string `yaml:"translationsPath,omitempty"`
+	// ColorScheme of the pages (by default `auto`):
+	// - `auto`: Light or dark based on the visitor preferences.
+	// - `light`: Always light.
+	// - `dark`: Always dark.
+	ColorScheme string `yaml:"colorScheme,omitempty" jsonschema:"enum=auto,enum=light,enum=dark"`
+	// HighContrast uses a high contrast variant of the color scheme (by default false).
+	HighContrast bool `yaml:"highContrast,omitempty"`
+}
+
+type StactusV1Feed struct {
+	// HistoryItems is the max number of entries on each feed (by default 25).
+	HistoryItems int `yaml:"historyItems,omitempty"`
+	// Mode is how the incidents are published on the feeds (by default `incident`):
+	// - `incident`: An entry per incident, updated with each incident update.
+	// - `update`: An entry per incident update (update stream).
+	Mode string `yaml:"mode,omitempty" jsonschema:"enum=incident,enum=update"`
+	// Atom enables the Atom feeds (by default true).
+	Atom *bool `yaml:"atom,omitempty"`
+	// RSS enables the RSS 2.0 feeds (by default true).
+	RSS *bool `yaml:"rss,omitempty"`
+	// JSON enables the JSON Feed 1.1 feeds (by default true).
+	JSON *bool `yaml:"json,omitempty"`
+}
+
+type StactusV1Metrics struct {
+	// Format is the exposition format of the metrics (by default `prometheus`):
+	// - `prometheus`: Prometheus text format.
+	// - `openmetrics`: OpenMetrics text format.
+	Format string `yaml:"format,omitempty" jsonschema:"enum=prometheus,enum=openmetrics"`
+	// Namespace is the prefix of the metric names (by default `stactus`).
+	Namespace string `yaml:"namespace,omitempty"`
+	// Labels are extra constant labels added to all the metrics (e.g: `environment`, `region`...).
+	Labels map[string]string `yaml:"labels,omitempty"`
+}
+
+type StactusV1SEO struct {
+	// NoIndex asks the search engines to not index the status page, the
+	// `robots.txt` will disallow everything and the pages will have `noindex` meta tags
+	// (e.g: internal status pages).
+	NoIndex bool `yaml:"noIndex,omitempty"`
+	// Description of the status page used on the meta tags (by default based on the current status).
+	Description string `yaml:"description,omitempty"`
+	// Robots is a custom `robots.txt` content (by default allows everything and points to the sitemap).
+	Robots string `yaml:"robots,omitempty"`
+}
+
+type StactusV1Markdown struct {
+	// Tables enables the GFM tables (by default true).
+	Tables *bool `yaml:"tables,omitempty"`
+	// Strikethrough enables the GFM strikethrough, `~~text~~` (by default true).
+	Strikethrough *bool `yaml:"strikethrough,omitempty"`
+	// Autolinks enables the GFM autolinks, plain URLs are converted to links (by default true).
+	Autolinks *bool `yaml:"autolinks,omitempty"`
+	// TaskLists enables the GFM task lists, `- [x] task` (by default true).
+	TaskLists *bool `yaml:"taskLists,omitempty"`
+	// Footnotes enables the footnotes, `text[^1]` (by default true).
+	Footnotes *bool `yaml:"footnotes,omitempty"`
+	// RawHTML renders the raw HTML of the contents instead of omitting it (by default false). The
+	// HTML is always sanitized with an allowlist, so scripts, styles, iframes, forms... are removed.
+	RawHTML bool `yaml:"rawHTML,omitempty"`
+	// HeadingAnchors adds a link to each heading so it can be shared (by default false).
+	HeadingAnchors bool `yaml:"headingAnchors,omitempty"`
+	// CodeHighlightStyle is the Chroma style used to highlight the code blocks (e.g: `github`,
+	// `monokai`, `dracula`), by default the code blocks are not highlighted.
+	CodeHighlightStyle string `yaml:"codeHighlightStyle,omitempty"`
+	// ExternalLinks are the settings of the links to other sites.
+	ExternalLinks *StactusV1MarkdownExternalLinks `yaml:"externalLinks,omitempty"`
+}
+
+type StactusV1MarkdownExternalLinks struct {
+	// Rel of the external links (by default `noopener noreferrer`).
+	Rel string `yaml:"rel,omitempty"`
+	// Target of the external links (by default not set, the link is opened on the same tab).
+	Target string `yaml:"target,omitempty" jsonschema:"enum=_blank,enum=_self,enum=_parent,enum=_top"`
 }
@@ -0,0 +1,28 @@
+package api
+
+const (
+	WorkspaceVersionV1 = "workspace/v1"
+)
+
+// WorkspaceV1 is a set of status pages generated from the same repository that share
+// the incidents.
+type WorkspaceV1 struct {
+	Version string `yaml:"version" jsonschema:"required,enum=workspace/v1"`
+	// IncidentsPath is the directory of the incidents shared by all the pages, relative to the
+	// workspace file (by default `incidents`). Each page will only have the incidents of its
+	// systems and the ones without systems.
+	IncidentsPath string            `yaml:"incidentsPath,omitempty"`
+	Pages         []WorkspaceV1Page `yaml:"pages" jsonschema:"required"`
+}
+
+type WorkspaceV1Page struct {
+	// ID of the page, only lowercase alphanumeric, `-` and `_` are allowed. `serve` will
+	// serve the page under this path (e.g: `/product-a`).
+	ID string `yaml:"id" jsonschema:"required"`
+	// StactusFile is the path of the page stactus file, relative to the workspace file.
+	StactusFile string `yaml:"stactusFile" jsonschema:"required"`
+	// OutPath is the directory where the page will be generated, relative to the generation
+	// out path (by default the page ID). It can't be outside of the out path nor overlap with
+	// the other pages out paths.
+	OutPath string `yaml:"outPath,omitempty"`
+}